@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.33.0
 // 	protoc        (unknown)
 // source: oapi/v1/file.proto
 
@@ -29,7 +29,7 @@ type FileOptions struct {
 	// The default host for all services and methods defined in a file. This can
 	// be overridden by the service or a method definition.
 	//
-	// Deprecated: Do not use.
+	// Deprecated: Marked as deprecated in oapi/v1/file.proto.
 	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
 	// The default prefix for all services and methods in a file. This can be
 	// overridden by the service or a method definition.
@@ -76,7 +76,7 @@ func (*FileOptions) Descriptor() ([]byte, []int) {
 	return file_oapi_v1_file_proto_rawDescGZIP(), []int{0}
 }
 
-// Deprecated: Do not use.
+// Deprecated: Marked as deprecated in oapi/v1/file.proto.
 func (x *FileOptions) GetHost() string {
 	if x != nil {
 		return x.Host
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.33.0
 // 	protoc        (unknown)
 // source: oapi/v1/method.proto
 
@@ -29,7 +29,6 @@ type MethodOptions struct {
 	// The value of the method defined is the name to be appended or full path.
 	//
 	// Types that are assignable to Method:
-	//
 	//	*MethodOptions_Get
 	//	*MethodOptions_Put
 	//	*MethodOptions_Post
@@ -39,7 +38,7 @@ type MethodOptions struct {
 	// The host to use for the current method. This overrides any higher defined
 	// default_host value.
 	//
-	// Deprecated: Do not use.
+	// Deprecated: Marked as deprecated in oapi/v1/method.proto.
 	Host string `protobuf:"bytes,6,opt,name=host,proto3" json:"host,omitempty"`
 	// Specified content type for the method.
 	ContentType string `protobuf:"bytes,7,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
@@ -67,6 +66,15 @@ type MethodOptions struct {
 	// The servers to add to the existing server list. This will combine higher
 	// level defined servers with the ones defined here.
 	AddServers []*Server `protobuf:"bytes,18,rep,name=add_servers,json=addServers,proto3" json:"add_servers,omitempty"`
+	// Expand the fields of the input message into query parameters instead of
+	// dropping them or sending them in a body. Only applies to GET and DELETE.
+	// This overrides the input_query generator option.
+	InputQuery *bool `protobuf:"varint,19,opt,name=input_query,json=inputQuery,proto3,oneof" json:"input_query,omitempty"`
+	// Add the method as a webhook named by its path instead of a path. Requires
+	// the openapi_version generator option to be 3.1.
+	Webhook bool `protobuf:"varint,20,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	// How the security is combined with the one of the service.
+	SecurityMode SecurityMode `protobuf:"varint,21,opt,name=security_mode,json=securityMode,proto3,enum=oapi.v1.SecurityMode" json:"security_mode,omitempty"`
 }
 
 func (x *MethodOptions) Reset() {
@@ -143,7 +151,7 @@ func (x *MethodOptions) GetPatch() string {
 	return ""
 }
 
-// Deprecated: Do not use.
+// Deprecated: Marked as deprecated in oapi/v1/method.proto.
 func (x *MethodOptions) GetHost() string {
 	if x != nil {
 		return x.Host
@@ -235,6 +243,27 @@ func (x *MethodOptions) GetAddServers() []*Server {
 	return nil
 }
 
+func (x *MethodOptions) GetInputQuery() bool {
+	if x != nil && x.InputQuery != nil {
+		return *x.InputQuery
+	}
+	return false
+}
+
+func (x *MethodOptions) GetWebhook() bool {
+	if x != nil {
+		return x.Webhook
+	}
+	return false
+}
+
+func (x *MethodOptions) GetSecurityMode() SecurityMode {
+	if x != nil {
+		return x.SecurityMode
+	}
+	return SecurityMode_SECURITY_MODE_UNSPECIFIED
+}
+
 type isMethodOptions_Method interface {
 	isMethodOptions_Method()
 }
@@ -302,7 +331,7 @@ var file_oapi_v1_method_proto_rawDesc = []byte{
 	0x65, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x6f, 0x61, 0x70, 0x69,
 	0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x1a, 0x14, 0x6f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc3, 0x05, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcf, 0x06, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
 	0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x03, 0x67, 0x65,
 	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x67, 0x65, 0x74, 0x12, 0x12,
 	0x0a, 0x03, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x70,
@@ -346,23 +375,31 @@ var file_oapi_v1_method_proto_rawDesc = []byte{
 	0x72, 0x73, 0x12, 0x30, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
 	0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76,
 	0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x3a, 0x4f,
-	0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9e, 0x28, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x42,
-	0x99, 0x01, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x2e, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x42,
-	0x0b, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x40,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65, 0x63, 0x68, 0x6e,
-	0x69, 0x63, 0x61, 0x6c, 0x6c, 0x79, 0x6a, 0x6f, 0x73, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70,
-	0x69, 0x2f, 0x6f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x6f, 0x61, 0x70, 0x69, 0x76, 0x31,
-	0xa2, 0x02, 0x03, 0x4f, 0x58, 0x58, 0xaa, 0x02, 0x07, 0x4f, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x31,
-	0xca, 0x02, 0x07, 0x4f, 0x61, 0x70, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x13, 0x4f, 0x61, 0x70,
-	0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0xea, 0x02, 0x08, 0x4f, 0x61, 0x70, 0x69, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x76, 0x65, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x0a, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x77, 0x65, 0x62,
+	0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x3a, 0x0a, 0x0d, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6f, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x0c, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4d, 0x6f, 0x64, 0x65,
+	0x42, 0x08, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x3a, 0x4f, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9e, 0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6f, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x42, 0x99, 0x01, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6d, 0x2e, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65, 0x63, 0x68, 0x6e, 0x69, 0x63, 0x61, 0x6c,
+	0x6c, 0x79, 0x6a, 0x6f, 0x73, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65,
+	0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x6f, 0x61, 0x70, 0x69, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x4f,
+	0x58, 0x58, 0xaa, 0x02, 0x07, 0x4f, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x07, 0x4f,
+	0x61, 0x70, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x13, 0x4f, 0x61, 0x70, 0x69, 0x5c, 0x56, 0x31,
+	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x08, 0x4f,
+	0x61, 0x70, 0x69, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -383,23 +420,25 @@ var file_oapi_v1_method_proto_goTypes = []interface{}{
 	(*Parameter)(nil),                  // 1: oapi.v1.Parameter
 	(*Security)(nil),                   // 2: oapi.v1.Security
 	(*Server)(nil),                     // 3: oapi.v1.Server
-	(*descriptorpb.MethodOptions)(nil), // 4: google.protobuf.MethodOptions
+	(SecurityMode)(0),                  // 4: oapi.v1.SecurityMode
+	(*descriptorpb.MethodOptions)(nil), // 5: google.protobuf.MethodOptions
 }
 var file_oapi_v1_method_proto_depIdxs = []int32{
-	1, // 0: oapi.v1.MethodOptions.path_parameter:type_name -> oapi.v1.Parameter
-	1, // 1: oapi.v1.MethodOptions.query_parameter:type_name -> oapi.v1.Parameter
-	1, // 2: oapi.v1.MethodOptions.header_parameter:type_name -> oapi.v1.Parameter
-	1, // 3: oapi.v1.MethodOptions.cookie_parameter:type_name -> oapi.v1.Parameter
-	2, // 4: oapi.v1.MethodOptions.security:type_name -> oapi.v1.Security
-	3, // 5: oapi.v1.MethodOptions.servers:type_name -> oapi.v1.Server
-	3, // 6: oapi.v1.MethodOptions.add_servers:type_name -> oapi.v1.Server
-	4, // 7: oapi.v1.method:extendee -> google.protobuf.MethodOptions
-	0, // 8: oapi.v1.method:type_name -> oapi.v1.MethodOptions
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	8, // [8:9] is the sub-list for extension type_name
-	7, // [7:8] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	1,  // 0: oapi.v1.MethodOptions.path_parameter:type_name -> oapi.v1.Parameter
+	1,  // 1: oapi.v1.MethodOptions.query_parameter:type_name -> oapi.v1.Parameter
+	1,  // 2: oapi.v1.MethodOptions.header_parameter:type_name -> oapi.v1.Parameter
+	1,  // 3: oapi.v1.MethodOptions.cookie_parameter:type_name -> oapi.v1.Parameter
+	2,  // 4: oapi.v1.MethodOptions.security:type_name -> oapi.v1.Security
+	3,  // 5: oapi.v1.MethodOptions.servers:type_name -> oapi.v1.Server
+	3,  // 6: oapi.v1.MethodOptions.add_servers:type_name -> oapi.v1.Server
+	4,  // 7: oapi.v1.MethodOptions.security_mode:type_name -> oapi.v1.SecurityMode
+	5,  // 8: oapi.v1.method:extendee -> google.protobuf.MethodOptions
+	0,  // 9: oapi.v1.method:type_name -> oapi.v1.MethodOptions
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	9,  // [9:10] is the sub-list for extension type_name
+	8,  // [8:9] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_oapi_v1_method_proto_init() }
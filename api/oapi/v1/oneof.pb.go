@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: oapi/v1/oneof.proto
+
+package oapiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OneofOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the field of the message that tells which field of the oneof is
+	// set. It must be a string or enum field outside of any oneof, since only
+	// fields of the message are written by protojson.
+	Discriminator string `protobuf:"bytes,1,opt,name=discriminator,proto3" json:"discriminator,omitempty"`
+}
+
+func (x *OneofOptions) Reset() {
+	*x = OneofOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_oapi_v1_oneof_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OneofOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneofOptions) ProtoMessage() {}
+
+func (x *OneofOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_oapi_v1_oneof_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OneofOptions.ProtoReflect.Descriptor instead.
+func (*OneofOptions) Descriptor() ([]byte, []int) {
+	return file_oapi_v1_oneof_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OneofOptions) GetDiscriminator() string {
+	if x != nil {
+		return x.Discriminator
+	}
+	return ""
+}
+
+var file_oapi_v1_oneof_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.OneofOptions)(nil),
+		ExtensionType: (*OneofOptions)(nil),
+		Field:         5150,
+		Name:          "oapi.v1.oneof",
+		Tag:           "bytes,5150,opt,name=oneof",
+		Filename:      "oapi/v1/oneof.proto",
+	},
+}
+
+// Extension fields to descriptorpb.OneofOptions.
+var (
+	// optional oapi.v1.OneofOptions oneof = 5150;
+	E_Oneof = &file_oapi_v1_oneof_proto_extTypes[0]
+)
+
+var File_oapi_v1_oneof_proto protoreflect.FileDescriptor
+
+var file_oapi_v1_oneof_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x6f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x20,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x34, 0x0a, 0x0c, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x69, 0x73, 0x63, 0x72, 0x69, 0x6d,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x3a, 0x4b, 0x0a, 0x05, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x12,
+	0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9e,
+	0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x6f, 0x6e,
+	0x65, 0x6f, 0x66, 0x42, 0x98, 0x01, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x2e, 0x6f, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x42, 0x0a, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
+	0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65,
+	0x63, 0x68, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x6c, 0x79, 0x6a, 0x6f, 0x73, 0x68, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x6f, 0x61, 0x70,
+	0x69, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x4f, 0x58, 0x58, 0xaa, 0x02, 0x07, 0x4f, 0x61, 0x70, 0x69,
+	0x2e, 0x56, 0x31, 0xca, 0x02, 0x07, 0x4f, 0x61, 0x70, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x13,
+	0x4f, 0x61, 0x70, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0xea, 0x02, 0x08, 0x4f, 0x61, 0x70, 0x69, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_oapi_v1_oneof_proto_rawDescOnce sync.Once
+	file_oapi_v1_oneof_proto_rawDescData = file_oapi_v1_oneof_proto_rawDesc
+)
+
+func file_oapi_v1_oneof_proto_rawDescGZIP() []byte {
+	file_oapi_v1_oneof_proto_rawDescOnce.Do(func() {
+		file_oapi_v1_oneof_proto_rawDescData = protoimpl.X.CompressGZIP(file_oapi_v1_oneof_proto_rawDescData)
+	})
+	return file_oapi_v1_oneof_proto_rawDescData
+}
+
+var file_oapi_v1_oneof_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_oapi_v1_oneof_proto_goTypes = []interface{}{
+	(*OneofOptions)(nil),              // 0: oapi.v1.OneofOptions
+	(*descriptorpb.OneofOptions)(nil), // 1: google.protobuf.OneofOptions
+}
+var file_oapi_v1_oneof_proto_depIdxs = []int32{
+	1, // 0: oapi.v1.oneof:extendee -> google.protobuf.OneofOptions
+	0, // 1: oapi.v1.oneof:type_name -> oapi.v1.OneofOptions
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_oapi_v1_oneof_proto_init() }
+func file_oapi_v1_oneof_proto_init() {
+	if File_oapi_v1_oneof_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_oapi_v1_oneof_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OneofOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_oapi_v1_oneof_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_oapi_v1_oneof_proto_goTypes,
+		DependencyIndexes: file_oapi_v1_oneof_proto_depIdxs,
+		MessageInfos:      file_oapi_v1_oneof_proto_msgTypes,
+		ExtensionInfos:    file_oapi_v1_oneof_proto_extTypes,
+	}.Build()
+	File_oapi_v1_oneof_proto = out.File
+	file_oapi_v1_oneof_proto_rawDesc = nil
+	file_oapi_v1_oneof_proto_goTypes = nil
+	file_oapi_v1_oneof_proto_depIdxs = nil
+}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.33.0
 // 	protoc        (unknown)
 // source: oapi/v1/service.proto
 
@@ -29,7 +29,7 @@ type ServiceOptions struct {
 	// The default host for all methods within the service. This overrides the
 	// file definition and can be overridden by a method definition.
 	//
-	// Deprecated: Do not use.
+	// Deprecated: Marked as deprecated in oapi/v1/service.proto.
 	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
 	// This prefix is applied to each method in the service. Can be overridden by
 	// a method definition.
@@ -61,6 +61,8 @@ type ServiceOptions struct {
 	// level defined servers, ones defined in "servers", and the ones defined
 	// here.
 	AddServers []*Server `protobuf:"bytes,13,rep,name=add_servers,json=addServers,proto3" json:"add_servers,omitempty"`
+	// How the security is combined with the one of the file.
+	SecurityMode SecurityMode `protobuf:"varint,14,opt,name=security_mode,json=securityMode,proto3,enum=oapi.v1.SecurityMode" json:"security_mode,omitempty"`
 }
 
 func (x *ServiceOptions) Reset() {
@@ -95,7 +97,7 @@ func (*ServiceOptions) Descriptor() ([]byte, []int) {
 	return file_oapi_v1_service_proto_rawDescGZIP(), []int{0}
 }
 
-// Deprecated: Do not use.
+// Deprecated: Marked as deprecated in oapi/v1/service.proto.
 func (x *ServiceOptions) GetHost() string {
 	if x != nil {
 		return x.Host
@@ -187,6 +189,13 @@ func (x *ServiceOptions) GetAddServers() []*Server {
 	return nil
 }
 
+func (x *ServiceOptions) GetSecurityMode() SecurityMode {
+	if x != nil {
+		return x.SecurityMode
+	}
+	return SecurityMode_SECURITY_MODE_UNSPECIFIED
+}
+
 var file_oapi_v1_service_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
@@ -215,7 +224,7 @@ var file_oapi_v1_service_proto_rawDesc = []byte{
 	0x6d, 0x65, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x6f, 0x61, 0x70,
 	0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x6f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd6, 0x04, 0x0a, 0x0e, 0x53, 0x65,
+	0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x92, 0x05, 0x0a, 0x0e, 0x53, 0x65,
 	0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x04,
 	0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x04,
 	0x68, 0x6f, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02,
@@ -253,22 +262,26 @@ var file_oapi_v1_service_proto_rawDesc = []byte{
 	0x12, 0x30, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18,
 	0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
 	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x73, 0x3a, 0x53, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1f, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9e,
-	0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x9a, 0x01, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x2e,
-	0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65, 0x63, 0x68, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x6c, 0x79, 0x6a,
-	0x6f, 0x73, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f,
-	0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x61, 0x70, 0x69, 0x2f,
-	0x76, 0x31, 0x3b, 0x6f, 0x61, 0x70, 0x69, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x4f, 0x58, 0x58, 0xaa,
-	0x02, 0x07, 0x4f, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x07, 0x4f, 0x61, 0x70, 0x69,
-	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x13, 0x4f, 0x61, 0x70, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50,
-	0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x08, 0x4f, 0x61, 0x70, 0x69,
-	0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x73, 0x12, 0x3a, 0x0a, 0x0d, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6f, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x0c, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x3a, 0x53,
+	0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9e, 0x28, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x42, 0x9a, 0x01, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x2e, 0x6f, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x42, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x74, 0x65, 0x63, 0x68, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x6c, 0x79, 0x6a, 0x6f, 0x73, 0x68, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61,
+	0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x6f,
+	0x61, 0x70, 0x69, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x4f, 0x58, 0x58, 0xaa, 0x02, 0x07, 0x4f, 0x61,
+	0x70, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x07, 0x4f, 0x61, 0x70, 0x69, 0x5c, 0x56, 0x31, 0xe2,
+	0x02, 0x13, 0x4f, 0x61, 0x70, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x08, 0x4f, 0x61, 0x70, 0x69, 0x3a, 0x3a, 0x56, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -289,23 +302,25 @@ var file_oapi_v1_service_proto_goTypes = []interface{}{
 	(*Parameter)(nil),                   // 1: oapi.v1.Parameter
 	(*Security)(nil),                    // 2: oapi.v1.Security
 	(*Server)(nil),                      // 3: oapi.v1.Server
-	(*descriptorpb.ServiceOptions)(nil), // 4: google.protobuf.ServiceOptions
+	(SecurityMode)(0),                   // 4: oapi.v1.SecurityMode
+	(*descriptorpb.ServiceOptions)(nil), // 5: google.protobuf.ServiceOptions
 }
 var file_oapi_v1_service_proto_depIdxs = []int32{
-	1, // 0: oapi.v1.ServiceOptions.path_parameter:type_name -> oapi.v1.Parameter
-	1, // 1: oapi.v1.ServiceOptions.query_parameter:type_name -> oapi.v1.Parameter
-	1, // 2: oapi.v1.ServiceOptions.header_parameter:type_name -> oapi.v1.Parameter
-	1, // 3: oapi.v1.ServiceOptions.cookie_parameter:type_name -> oapi.v1.Parameter
-	2, // 4: oapi.v1.ServiceOptions.security:type_name -> oapi.v1.Security
-	3, // 5: oapi.v1.ServiceOptions.servers:type_name -> oapi.v1.Server
-	3, // 6: oapi.v1.ServiceOptions.add_servers:type_name -> oapi.v1.Server
-	4, // 7: oapi.v1.service:extendee -> google.protobuf.ServiceOptions
-	0, // 8: oapi.v1.service:type_name -> oapi.v1.ServiceOptions
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	8, // [8:9] is the sub-list for extension type_name
-	7, // [7:8] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	1,  // 0: oapi.v1.ServiceOptions.path_parameter:type_name -> oapi.v1.Parameter
+	1,  // 1: oapi.v1.ServiceOptions.query_parameter:type_name -> oapi.v1.Parameter
+	1,  // 2: oapi.v1.ServiceOptions.header_parameter:type_name -> oapi.v1.Parameter
+	1,  // 3: oapi.v1.ServiceOptions.cookie_parameter:type_name -> oapi.v1.Parameter
+	2,  // 4: oapi.v1.ServiceOptions.security:type_name -> oapi.v1.Security
+	3,  // 5: oapi.v1.ServiceOptions.servers:type_name -> oapi.v1.Server
+	3,  // 6: oapi.v1.ServiceOptions.add_servers:type_name -> oapi.v1.Server
+	4,  // 7: oapi.v1.ServiceOptions.security_mode:type_name -> oapi.v1.SecurityMode
+	5,  // 8: oapi.v1.service:extendee -> google.protobuf.ServiceOptions
+	0,  // 9: oapi.v1.service:type_name -> oapi.v1.ServiceOptions
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	9,  // [9:10] is the sub-list for extension type_name
+	8,  // [8:9] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_oapi_v1_service_proto_init() }
@@ -1,12 +1,13 @@
 module github.com/technicallyjosh/protoc-gen-openapi
 
-go 1.20
+go 1.22
 
 require (
 	github.com/getkin/kin-openapi v0.120.0
 	github.com/josephburnett/jd v1.7.1
-	github.com/stretchr/objx v0.5.1
-	github.com/stretchr/testify v1.8.4
+	github.com/speakeasy-api/jsonpath v0.6.0
+	github.com/stretchr/testify v1.9.0
+	google.golang.org/genproto/googleapis/api v0.0.0-20240227224415-6ceb2ff114de
 	google.golang.org/protobuf v1.33.0
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -22,5 +23,6 @@ require (
 	github.com/perimeterx/marshmallow v1.1.5 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rogpeppe/go-internal v1.12.0 // indirect
+	google.golang.org/genproto v0.0.0-20240213162025-012b6fc9bca9 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
@@ -0,0 +1,153 @@
+// Package descriptorset builds plugin requests from serialized FileDescriptorSets and buf images so
+// the generator can run without protoc.
+package descriptorset
+
+import (
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const (
+	// bufExtensionNumber is the number of the buf_extension field buf images add to each file.
+	bufExtensionNumber = 8042
+	// isImportNumber is the number of the is_import field of the buf extension.
+	isImportNumber = 1
+)
+
+// NewRequest returns the request protoc would send for the files of the descriptor set at filename.
+// The set must include the imports of the files, like protoc's --include_imports does. Buf images
+// are FileDescriptorSets too and their files that aren't imports are generated. Otherwise, the
+// files to generate default to the ones no other file of the set imports.
+func NewRequest(filename string, filesToGenerate []string, parameter string) (*pluginpb.CodeGeneratorRequest, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+
+	err = proto.Unmarshal(data, set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor set '%s': %w", filename, err)
+	}
+
+	if len(set.File) == 0 {
+		return nil, fmt.Errorf("descriptor set '%s' has no files", filename)
+	}
+
+	if len(filesToGenerate) == 0 {
+		filesToGenerate = getFilesToGenerate(set)
+	}
+
+	names := make(map[string]bool, len(set.File))
+	for _, file := range set.File {
+		names[file.GetName()] = true
+	}
+
+	for _, name := range filesToGenerate {
+		if !names[name] {
+			return nil, fmt.Errorf("file '%s' isn't in descriptor set '%s'", name, filename)
+		}
+	}
+
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: filesToGenerate,
+		Parameter:      proto.String(parameter),
+		ProtoFile:      set.File,
+	}, nil
+}
+
+// getFilesToGenerate returns the files of a buf image that aren't imports or, for other sets, the
+// files no other file imports.
+func getFilesToGenerate(set *descriptorpb.FileDescriptorSet) []string {
+	var files []string
+
+	isImage := false
+
+	for _, file := range set.File {
+		isImport, ok := getBufIsImport(file)
+		if !ok {
+			continue
+		}
+
+		isImage = true
+
+		if !isImport {
+			files = append(files, file.GetName())
+		}
+	}
+
+	if isImage {
+		return files
+	}
+
+	imported := make(map[string]bool)
+	for _, file := range set.File {
+		for _, dependency := range file.Dependency {
+			imported[dependency] = true
+		}
+	}
+
+	for _, file := range set.File {
+		if !imported[file.GetName()] {
+			files = append(files, file.GetName())
+		}
+	}
+
+	return files
+}
+
+// getBufIsImport returns the is_import field of the buf extension of the file and whether the file
+// has the extension at all. It's an unknown field since buf's types aren't a dependency.
+func getBufIsImport(file *descriptorpb.FileDescriptorProto) (bool, bool) {
+	extension, ok := findField(file.ProtoReflect().GetUnknown(), bufExtensionNumber, protowire.BytesType)
+	if !ok {
+		return false, false
+	}
+
+	isImport, ok := findField(extension, isImportNumber, protowire.VarintType)
+	if !ok {
+		return false, true
+	}
+
+	value, n := protowire.ConsumeVarint(isImport)
+
+	return n > 0 && value != 0, true
+}
+
+// findField returns the value of the last field with the number and type in the encoded message.
+// Bytes fields are returned without their length.
+func findField(data []byte, number protowire.Number, typ protowire.Type) ([]byte, bool) {
+	var value []byte
+	found := false
+
+	for len(data) > 0 {
+		fieldNumber, fieldType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, false
+		}
+		data = data[n:]
+
+		m := protowire.ConsumeFieldValue(fieldNumber, fieldType, data)
+		if m < 0 {
+			return nil, false
+		}
+
+		if fieldNumber == number && fieldType == typ {
+			value, found = data[:m], true
+
+			if typ == protowire.BytesType {
+				value, _ = protowire.ConsumeBytes(value)
+			}
+		}
+
+		data = data[m:]
+	}
+
+	return value, found
+}
@@ -0,0 +1,466 @@
+// Package diff compares two generated OpenAPI documents and classifies the changes as breaking or
+// non-breaking for clients of the API.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// direction is whether a schema is sent by clients or received by them, which decides whether
+// narrowing or widening it breaks them.
+type direction int
+
+const (
+	// request schemas break clients when they accept less than before.
+	request direction = iota
+	// response schemas break clients when they return more than before.
+	response
+)
+
+// Change is a difference between two documents.
+type Change struct {
+	// Location is where the change is, e.g. "GET /v1/users response 200 body.users[].id".
+	Location string
+	Message  string
+	Breaking bool
+}
+
+// String returns the change as "breaking: location: message".
+func (c Change) String() string {
+	kind := "non-breaking"
+	if c.Breaking {
+		kind = "breaking"
+	}
+
+	return fmt.Sprintf("%s: %s: %s", kind, c.Location, c.Message)
+}
+
+// Load loads an OpenAPI 3.0, OpenAPI 3.1 or Swagger 2.0 document from a YAML or JSON file. OpenAPI
+// 3.1 and Swagger 2.0 documents are converted to OpenAPI 3.0 so all of them can be compared the
+// same way.
+func Load(filename string) (*openapi3.T, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		OpenAPI string `yaml:"openapi"`
+		Swagger string `yaml:"swagger"`
+	}
+
+	err = yaml.Unmarshal(data, &header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", filename, err)
+	}
+
+	switch {
+	case strings.HasPrefix(header.OpenAPI, "3.0"), strings.HasPrefix(header.OpenAPI, "3.1"):
+		loader := openapi3.NewLoader()
+		// Documents of a shared_components run reference the components file.
+		loader.IsExternalRefsAllowed = true
+
+		if strings.HasPrefix(header.OpenAPI, "3.1") {
+			loader.ReadFromURIFunc = readOpenAPI31
+		}
+
+		doc, err := loader.LoadFromFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load '%s': %w", filename, err)
+		}
+
+		return doc, nil
+	case header.Swagger == "2.0":
+		return loadSwagger2(filename, data)
+	default:
+		return nil, fmt.Errorf("'%s' isn't an OpenAPI 3.0 or 3.1 or Swagger 2.0 document", filename)
+	}
+}
+
+// loadSwagger2 converts the Swagger 2.0 document to OpenAPI 3.0 and resolves its references.
+func loadSwagger2(filename string, data []byte) (*openapi3.T, error) {
+	var raw any
+	err := yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", filename, err)
+	}
+
+	// openapi2.T is only unmarshaled from JSON.
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", filename, err)
+	}
+
+	var doc2 openapi2.T
+	err = json.Unmarshal(jsonData, &doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", filename, err)
+	}
+
+	doc, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert '%s': %w", filename, err)
+	}
+
+	err = openapi3.NewLoader().ResolveRefsIn(doc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load '%s': %w", filename, err)
+	}
+
+	return doc, nil
+}
+
+// comparer collects the changes between two documents.
+type comparer struct {
+	changes []Change
+	// comparing holds the schemas being compared so recursive schemas are only compared once.
+	comparing map[[2]*openapi3.Schema]bool
+}
+
+// Compare returns the changes from the base document to the revision in order of their paths.
+// Schemas are compared through the operations that use them, so a change to a shared schema is
+// reported for each operation.
+func Compare(base, revision *openapi3.T) []Change {
+	c := &comparer{
+		comparing: make(map[[2]*openapi3.Schema]bool),
+	}
+
+	for _, path := range sortedKeys(base.Paths) {
+		revisionItem := revision.Paths.Find(path)
+		if revisionItem == nil {
+			c.add(path, true, "path removed")
+			continue
+		}
+
+		baseItem := base.Paths[path]
+		baseOperations := baseItem.Operations()
+		revisionOperations := revisionItem.Operations()
+
+		for _, method := range sortedKeys(baseOperations) {
+			location := method + " " + path
+
+			revisionOp, ok := revisionOperations[method]
+			if !ok {
+				c.add(location, true, "operation removed")
+				continue
+			}
+
+			c.compareOperation(location, baseItem, revisionItem, baseOperations[method], revisionOp)
+		}
+
+		for _, method := range sortedKeys(revisionOperations) {
+			if _, ok := baseOperations[method]; !ok {
+				c.add(method+" "+path, false, "operation added")
+			}
+		}
+	}
+
+	for _, path := range sortedKeys(revision.Paths) {
+		if base.Paths.Find(path) == nil {
+			c.add(path, false, "path added")
+		}
+	}
+
+	return c.changes
+}
+
+// add adds a change.
+func (c *comparer) add(location string, breaking bool, format string, args ...any) {
+	c.changes = append(c.changes, Change{
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+// compareOperation compares the parameters, request body and responses of an operation.
+func (c *comparer) compareOperation(location string, baseItem, revisionItem *openapi3.PathItem, base, revision *openapi3.Operation) {
+	baseParams := getParameters(baseItem, base)
+	revisionParams := getParameters(revisionItem, revision)
+
+	for _, key := range sortedKeys(baseParams) {
+		baseParam := baseParams[key]
+		paramLocation := fmt.Sprintf("%s %s parameter '%s'", location, baseParam.In, baseParam.Name)
+
+		revisionParam, ok := revisionParams[key]
+		if !ok {
+			c.add(paramLocation, true, "parameter removed")
+			continue
+		}
+
+		if !baseParam.Required && revisionParam.Required {
+			c.add(paramLocation, true, "parameter is now required")
+		} else if baseParam.Required && !revisionParam.Required {
+			c.add(paramLocation, false, "parameter is now optional")
+		}
+
+		c.compareSchema(paramLocation, baseParam.Schema, revisionParam.Schema, request)
+	}
+
+	for _, key := range sortedKeys(revisionParams) {
+		if _, ok := baseParams[key]; ok {
+			continue
+		}
+
+		revisionParam := revisionParams[key]
+		paramLocation := fmt.Sprintf("%s %s parameter '%s'", location, revisionParam.In, revisionParam.Name)
+
+		if revisionParam.Required {
+			c.add(paramLocation, true, "required parameter added")
+		} else {
+			c.add(paramLocation, false, "optional parameter added")
+		}
+	}
+
+	c.compareRequestBody(location+" request", base.RequestBody, revision.RequestBody)
+
+	for _, status := range sortedKeys(base.Responses) {
+		responseLocation := fmt.Sprintf("%s response %s", location, status)
+
+		revisionResponse := revision.Responses[status]
+		if revisionResponse == nil || revisionResponse.Value == nil {
+			c.add(responseLocation, true, "response removed")
+			continue
+		}
+
+		if base.Responses[status].Value == nil {
+			continue
+		}
+
+		c.compareContent(responseLocation, base.Responses[status].Value.Content, revisionResponse.Value.Content, response)
+	}
+
+	for _, status := range sortedKeys(revision.Responses) {
+		if _, ok := base.Responses[status]; !ok {
+			c.add(fmt.Sprintf("%s response %s", location, status), false, "response added")
+		}
+	}
+}
+
+// compareRequestBody compares the request bodies of an operation.
+func (c *comparer) compareRequestBody(location string, base, revision *openapi3.RequestBodyRef) {
+	switch {
+	case base == nil && revision == nil:
+		return
+	case base == nil:
+		if revision.Value != nil && revision.Value.Required {
+			c.add(location, true, "required body added")
+		} else {
+			c.add(location, false, "body added")
+		}
+
+		return
+	case revision == nil:
+		c.add(location, false, "body removed")
+		return
+	case base.Value == nil || revision.Value == nil:
+		return
+	}
+
+	if !base.Value.Required && revision.Value.Required {
+		c.add(location, true, "body is now required")
+	}
+
+	c.compareContent(location, base.Value.Content, revision.Value.Content, request)
+}
+
+// compareContent compares the schemas of the media types of a request or response.
+func (c *comparer) compareContent(location string, base, revision openapi3.Content, dir direction) {
+	for _, contentType := range sortedKeys(base) {
+		revisionMediaType, ok := revision[contentType]
+		if !ok {
+			c.add(location, true, "content type '%s' removed", contentType)
+			continue
+		}
+
+		c.compareSchema(location+" body", base[contentType].Schema, revisionMediaType.Schema, dir)
+	}
+
+	for _, contentType := range sortedKeys(revision) {
+		if _, ok := base[contentType]; !ok {
+			c.add(location, false, "content type '%s' added", contentType)
+		}
+	}
+}
+
+// compareSchema compares two schemas and their subschemas. Whether narrowing or widening breaks
+// clients depends on the direction.
+func (c *comparer) compareSchema(location string, baseRef, revisionRef *openapi3.SchemaRef, dir direction) {
+	if baseRef == nil || revisionRef == nil || baseRef.Value == nil || revisionRef.Value == nil {
+		return
+	}
+
+	base, revision := baseRef.Value, revisionRef.Value
+
+	pair := [2]*openapi3.Schema{base, revision}
+	if c.comparing[pair] {
+		return
+	}
+	c.comparing[pair] = true
+	defer delete(c.comparing, pair)
+
+	if base.Type != revision.Type {
+		c.add(location, true, "type changed from '%s' to '%s'", base.Type, revision.Type)
+		return
+	}
+
+	if base.Format != revision.Format {
+		c.add(location, true, "format changed from '%s' to '%s'", base.Format, revision.Format)
+	}
+
+	c.compareEnum(location, base.Enum, revision.Enum, dir)
+
+	if base.Nullable && !revision.Nullable {
+		c.add(location, dir == request, "no longer nullable")
+	} else if !base.Nullable && revision.Nullable {
+		c.add(location, dir == response, "now nullable")
+	}
+
+	baseRequired := toSet(base.Required)
+	revisionRequired := toSet(revision.Required)
+
+	for _, name := range sortedKeys(revision.Properties) {
+		propertyLocation := joinProperty(location, name)
+
+		if _, ok := base.Properties[name]; !ok {
+			if revisionRequired[name] {
+				c.add(propertyLocation, dir == request, "required property added")
+			} else {
+				c.add(propertyLocation, false, "property added")
+			}
+
+			continue
+		}
+
+		if !baseRequired[name] && revisionRequired[name] {
+			c.add(propertyLocation, dir == request, "property is now required")
+		} else if baseRequired[name] && !revisionRequired[name] {
+			c.add(propertyLocation, dir == response, "property is no longer required")
+		}
+
+		c.compareSchema(propertyLocation, base.Properties[name], revision.Properties[name], dir)
+	}
+
+	for _, name := range sortedKeys(base.Properties) {
+		if _, ok := revision.Properties[name]; !ok {
+			c.add(joinProperty(location, name), dir == response, "property removed")
+		}
+	}
+
+	c.compareSchema(location+"[]", base.Items, revision.Items, dir)
+	c.compareSchema(location+"{}", base.AdditionalProperties.Schema, revision.AdditionalProperties.Schema, dir)
+
+	for _, subSchemas := range [][2]openapi3.SchemaRefs{
+		{base.OneOf, revision.OneOf},
+		{base.AnyOf, revision.AnyOf},
+		{base.AllOf, revision.AllOf},
+	} {
+		// Subschemas can only be matched up by their position when there are as many as before.
+		if len(subSchemas[0]) != len(subSchemas[1]) {
+			continue
+		}
+
+		for i := range subSchemas[0] {
+			c.compareSchema(location, subSchemas[0][i], subSchemas[1][i], dir)
+		}
+	}
+}
+
+// compareEnum compares the allowed values of two schemas. Requests break clients when values are
+// removed and responses break them when values are added.
+func (c *comparer) compareEnum(location string, base, revision []any, dir direction) {
+	switch {
+	case len(base) == 0 && len(revision) == 0:
+		return
+	case len(base) == 0:
+		c.add(location, dir == request, "enum added")
+		return
+	case len(revision) == 0:
+		c.add(location, dir == response, "enum removed")
+		return
+	}
+
+	baseValues := make(map[string]bool)
+	for _, value := range base {
+		baseValues[fmt.Sprint(value)] = true
+	}
+
+	revisionValues := make(map[string]bool)
+	for _, value := range revision {
+		revisionValues[fmt.Sprint(value)] = true
+	}
+
+	var removed, added []string
+
+	for _, value := range sortedKeys(baseValues) {
+		if !revisionValues[value] {
+			removed = append(removed, value)
+		}
+	}
+
+	for _, value := range sortedKeys(revisionValues) {
+		if !baseValues[value] {
+			added = append(added, value)
+		}
+	}
+
+	if len(removed) > 0 {
+		c.add(location, dir == request, "enum values removed: %s", strings.Join(removed, ", "))
+	}
+
+	if len(added) > 0 {
+		c.add(location, dir == response, "enum values added: %s", strings.Join(added, ", "))
+	}
+}
+
+// getParameters returns the parameters of the path item and operation by location and name. The
+// ones of the operation override the ones of the path item.
+func getParameters(pathItem *openapi3.PathItem, op *openapi3.Operation) map[string]*openapi3.Parameter {
+	params := make(map[string]*openapi3.Parameter)
+
+	for _, paramRefs := range []openapi3.Parameters{pathItem.Parameters, op.Parameters} {
+		for _, paramRef := range paramRefs {
+			if paramRef.Value != nil {
+				params[paramRef.Value.In+" "+paramRef.Value.Name] = paramRef.Value
+			}
+		}
+	}
+
+	return params
+}
+
+// joinProperty returns the location of a property of the schema at the location.
+func joinProperty(location, name string) string {
+	return location + "." + name
+}
+
+// toSet returns the values as a set.
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,130 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// readOpenAPI31 reads the OpenAPI 3.1 document or components file and converts it to OpenAPI 3.0,
+// since kin-openapi only loads 3.0. It's used for every file the loader reads, so the components
+// file of a shared_components run is converted too.
+func readOpenAPI31(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	data, err := openapi3.DefaultReadFromURI(loader, location)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	err = yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	doc = convertToOpenAPI30(doc)
+
+	if root, ok := doc.(map[string]any); ok {
+		if _, ok := root["openapi"]; ok {
+			root["openapi"] = "3.0.3"
+		}
+
+		// Webhooks are called by the API rather than by its clients, so they aren't compared.
+		delete(root, "webhooks")
+		delete(root, "jsonSchemaDialect")
+	}
+
+	return json.Marshal(doc)
+}
+
+// convertToOpenAPI30 converts the OpenAPI 3.1 schema keywords under the node to their OpenAPI 3.0
+// equivalents. Mappings with keys that aren't strings are converted to string keys so they can be
+// marshaled to JSON.
+func convertToOpenAPI30(node any) any {
+	switch value := node.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(value))
+		for key, child := range value {
+			m[fmt.Sprint(key)] = child
+		}
+
+		return convertToOpenAPI30(m)
+	case map[string]any:
+		for key, child := range value {
+			value[key] = convertToOpenAPI30(child)
+		}
+
+		convertSchemaKeywords(value)
+	case []any:
+		for i, child := range value {
+			value[i] = convertToOpenAPI30(child)
+		}
+	}
+
+	return node
+}
+
+// convertSchemaKeywords converts the keywords of the mapping if it's a schema. The keywords are
+// only converted when their values have the 3.1 types, so other objects with the same keys, such as
+// the examples of media types, are left as they are.
+func convertSchemaKeywords(schema map[string]any) {
+	// Types with null are nullable types.
+	if types, ok := schema["type"].([]any); ok {
+		others := make([]any, 0, len(types))
+		for _, t := range types {
+			if t == "null" {
+				schema["nullable"] = true
+			} else {
+				others = append(others, t)
+			}
+		}
+
+		delete(schema, "type")
+		if len(others) == 1 {
+			schema["type"] = others[0]
+		}
+	}
+
+	// Nullable references are alternatives with null.
+	for _, key := range []string{"anyOf", "oneOf"} {
+		schemas, ok := schema[key].([]any)
+		if !ok {
+			continue
+		}
+
+		others := make([]any, 0, len(schemas))
+		for _, subSchema := range schemas {
+			if m, ok := subSchema.(map[string]any); ok && len(m) == 1 && m["type"] == "null" {
+				schema["nullable"] = true
+			} else {
+				others = append(others, subSchema)
+			}
+		}
+
+		schema[key] = others
+	}
+
+	if examples, ok := schema["examples"].([]any); ok {
+		delete(schema, "examples")
+		if len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+	}
+
+	for _, bound := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		switch value := schema[bound[0]].(type) {
+		case int, float64:
+			schema[bound[1]] = value
+			schema[bound[0]] = true
+		}
+	}
+
+	if value, ok := schema["const"]; ok {
+		if _, isMap := value.(map[string]any); !isMap {
+			delete(schema, "const")
+			schema["enum"] = []any{value}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/technicallyjosh/protoc-gen-openapi/internal/generator/util"
+)
+
+// mergeBaseFile loads the base document and merges the generated document into it. The rules are:
+//   - The OpenAPI version is the generated one.
+//   - Info fields of the base win and the generated ones fill in what the base leaves empty.
+//   - Servers, tags and security requirements of the base come first. Servers and tags with the
+//     same URL or name as one of the base are dropped.
+//   - Extensions of the base win.
+//   - Paths are merged by method and a method defined in both is an error.
+//   - Components are merged by name and a component defined in both is an error.
+func (g *Generator) mergeBaseFile(doc *openapi3.T) (*openapi3.T, error) {
+	base, err := openapi3.NewLoader().LoadFromFile(*g.config.BaseFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base_file '%s': %w", *g.config.BaseFile, err)
+	}
+
+	base.OpenAPI = doc.OpenAPI
+
+	if base.Info == nil {
+		base.Info = doc.Info
+	} else {
+		if base.Info.Title == "" {
+			base.Info.Title = doc.Info.Title
+		}
+
+		if base.Info.Description == "" {
+			base.Info.Description = doc.Info.Description
+		}
+
+		if base.Info.Version == "" {
+			base.Info.Version = doc.Info.Version
+		}
+	}
+
+	base.Servers = append(base.Servers, doc.Servers...)
+	base.Tags = append(base.Tags, doc.Tags...)
+	base.Security = append(base.Security, doc.Security...)
+
+	util.UniqueServers(base)
+	util.UniqueTags(base)
+
+	if base.Extensions == nil {
+		base.Extensions = make(map[string]any)
+	}
+
+	for key, value := range doc.Extensions {
+		if _, ok := base.Extensions[key]; !ok {
+			base.Extensions[key] = value
+		}
+	}
+
+	if base.Paths == nil {
+		base.Paths = make(openapi3.Paths)
+	}
+
+	for path, pathItem := range doc.Paths {
+		existingPath := base.Paths.Find(path)
+		if existingPath == nil {
+			base.Paths[path] = pathItem
+			continue
+		}
+
+		for method, op := range pathItem.Operations() {
+			if existingPath.GetOperation(method) != nil {
+				return nil, fmt.Errorf("duplicate method '%s' for path '%s' in base_file", method, path)
+			}
+
+			existingPath.SetOperation(method, op)
+		}
+	}
+
+	if base.Components == nil {
+		base.Components = new(openapi3.Components)
+	}
+
+	err = mergeBaseComponents(base.Components, doc.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	return base, nil
+}
+
+// mergeBaseComponents merges the generated components into the components of the base.
+func mergeBaseComponents(base, generated *openapi3.Components) error {
+	var err error
+
+	if base.Schemas, err = mergeBaseComponent("schema", base.Schemas, generated.Schemas); err != nil {
+		return err
+	}
+
+	if base.Parameters, err = mergeBaseComponent("parameter", base.Parameters, generated.Parameters); err != nil {
+		return err
+	}
+
+	if base.Headers, err = mergeBaseComponent("header", base.Headers, generated.Headers); err != nil {
+		return err
+	}
+
+	if base.RequestBodies, err = mergeBaseComponent("request body", base.RequestBodies, generated.RequestBodies); err != nil {
+		return err
+	}
+
+	if base.Responses, err = mergeBaseComponent("response", base.Responses, generated.Responses); err != nil {
+		return err
+	}
+
+	if base.SecuritySchemes, err = mergeBaseComponent("security scheme", base.SecuritySchemes, generated.SecuritySchemes); err != nil {
+		return err
+	}
+
+	if base.Examples, err = mergeBaseComponent("example", base.Examples, generated.Examples); err != nil {
+		return err
+	}
+
+	if base.Links, err = mergeBaseComponent("link", base.Links, generated.Links); err != nil {
+		return err
+	}
+
+	base.Callbacks, err = mergeBaseComponent("callback", base.Callbacks, generated.Callbacks)
+	return err
+}
+
+// mergeBaseComponent adds the generated components of a kind to the ones of the base. It errors if
+// both define a component with the same name.
+func mergeBaseComponent[M ~map[string]V, V any](kind string, base, generated M) (M, error) {
+	if base == nil {
+		base = make(M, len(generated))
+	}
+
+	for name, component := range generated {
+		if _, ok := base[name]; ok {
+			return nil, fmt.Errorf("%s '%s' is defined in both base_file and the generated components", kind, name)
+		}
+
+		base[name] = component
+	}
+
+	return base, nil
+}
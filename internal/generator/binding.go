@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+var (
+	// pathVariableRegexp is for finding variables in a path template. Variables can have a pattern
+	// like in google.api.http rules. e.g. "{name=shelves/*}".
+	pathVariableRegexp = regexp.MustCompile(`\{([^{}=]+)(=[^{}]*)?}`)
+)
+
+// parsePathTemplate returns the path with patterns removed from its variables and the names of
+// the variables in order.
+func parsePathTemplate(path string) (string, []string) {
+	variables := make([]string, 0)
+
+	path = pathVariableRegexp.ReplaceAllStringFunc(path, func(match string) string {
+		name := strings.TrimSpace(pathVariableRegexp.FindStringSubmatch(match)[1])
+		variables = append(variables, name)
+
+		return "{" + name + "}"
+	})
+
+	return path, variables
+}
+
+// findField returns the field of the message by its dotted path or nil if it isn't found. Each
+// part of the path can be the proto or JSON name of a field.
+func findField(message *protogen.Message, path string) *protogen.Field {
+	parts := strings.Split(path, ".")
+
+	for i, part := range parts {
+		var found *protogen.Field
+
+		for _, field := range message.Fields {
+			if string(field.Desc.Name()) == part || field.Desc.JSONName() == part {
+				found = field
+				break
+			}
+		}
+
+		if found == nil {
+			return nil
+		}
+
+		if i == len(parts)-1 {
+			return found
+		}
+
+		if found.Message == nil || found.Desc.IsList() || found.Desc.IsMap() {
+			return nil
+		}
+
+		message = found.Message
+	}
+
+	return nil
+}
+
+// buildPathParameters returns path parameters for the variables that match a field of the input
+// message and aren't defined already. The bound fields are returned by their dotted property names
+// so they can be left out of bodies and queries.
+func (g *Generator) buildPathParameters(doc *openapi3.T, message *protogen.Message, variables []string, defined openapi3.Parameters) (openapi3.Parameters, map[string]bool, error) {
+	params := make(openapi3.Parameters, 0)
+	bound := make(map[string]bool)
+
+	for _, variable := range variables {
+		field := findField(message, variable)
+		if field == nil {
+			continue
+		}
+
+		bound[g.getPropertyPath(message, variable)] = true
+
+		if hasParameter(defined, openapi3.ParameterInPath, variable) || hasParameter(params, openapi3.ParameterInPath, variable) {
+			continue
+		}
+
+		param, err := g.newFieldParameter(doc, field, openapi3.ParameterInPath, variable)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if param == nil {
+			return nil, nil, fmt.Errorf("path variable '%s' must be bound to a scalar field", variable)
+		}
+
+		params = append(params, param)
+	}
+
+	return params, bound, nil
+}
+
+// getPropertyPath returns the dotted path of a field path as property names.
+func (g *Generator) getPropertyPath(message *protogen.Message, path string) string {
+	parts := strings.Split(path, ".")
+	names := make([]string, 0, len(parts))
+
+	for i := range parts {
+		field := findField(message, strings.Join(parts[:i+1], "."))
+		names = append(names, g.getFieldName(field))
+	}
+
+	return strings.Join(names, ".")
+}
+
+// removeProperty removes the property by its dotted path from the schema and returns the schema.
+// Referenced schemas on the path are replaced by inline copies, so the components are left as they
+// are.
+func (g *Generator) removeProperty(doc *openapi3.T, schemaRef *openapi3.SchemaRef, path string) *openapi3.SchemaRef {
+	if schemaRef == nil {
+		return nil
+	}
+
+	schema := schemaRef.Value
+	if schemaRef.Ref != "" {
+		component := getRefSchema(doc, schemaRef.Ref)
+		if component == nil {
+			return schemaRef
+		}
+
+		schema = g.copySchema(component)
+		schemaRef = schema.NewRef()
+	}
+
+	if schema == nil {
+		return schemaRef
+	}
+
+	name, rest, nested := strings.Cut(path, ".")
+	if nested {
+		if property, ok := schema.Properties[name]; ok {
+			schema.Properties[name] = g.removeProperty(doc, property, rest)
+		}
+
+		return schemaRef
+	}
+
+	delete(schema.Properties, name)
+
+	required := make([]string, 0, len(schema.Required))
+	for _, requiredName := range schema.Required {
+		if requiredName != name {
+			required = append(required, requiredName)
+		}
+	}
+	schema.Required = required
+
+	return schemaRef
+}
+
+// copySchema returns a copy of the schema whose properties and required names can be changed
+// without changing the schema.
+func (g *Generator) copySchema(schema *openapi3.Schema) *openapi3.Schema {
+	copied := *schema
+	copied.Required = append([]string(nil), schema.Required...)
+
+	if schema.Properties != nil {
+		copied.Properties = make(openapi3.Schemas, len(schema.Properties))
+		for name, property := range schema.Properties {
+			copied.Properties[name] = property
+		}
+	}
+
+	g.propertyOrders[&copied] = g.propertyOrders[schema]
+
+	return &copied
+}
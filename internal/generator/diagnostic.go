@@ -0,0 +1,223 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	oapiv1 "github.com/technicallyjosh/protoc-gen-openapi/api/oapi/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// diagnostic is an error or warning along with the location in the proto files it comes from.
+type diagnostic struct {
+	// location is "file.proto:line:column". Diagnostics that don't come from a proto element, such
+	// as the ones of plugin options, have none.
+	location string
+	message  string
+	warning  bool
+}
+
+// Error returns the diagnostic as "file.proto:line:column: message" so editors and buf can point at
+// where it comes from.
+func (d *diagnostic) Error() string {
+	prefix := "protoc-gen-openapi"
+	if d.location != "" {
+		prefix = d.location
+	}
+
+	if d.warning {
+		return prefix + ": warning: " + d.message
+	}
+
+	return prefix + ": " + d.message
+}
+
+// errorAt returns an error diagnostic at the location.
+func errorAt(location, format string, args ...any) error {
+	return &diagnostic{
+		location: location,
+		message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// locatedAt returns the error as a diagnostic at the descriptor unless it's already a diagnostic.
+func locatedAt(desc protoreflect.Descriptor, err error) error {
+	var d *diagnostic
+	if errors.As(err, &d) {
+		return err
+	}
+
+	return errorAt(locate(desc), "%s", err.Error())
+}
+
+// locate returns the location of the descriptor.
+func locate(desc protoreflect.Descriptor) string {
+	return locateOption(desc)
+}
+
+// locateOption returns the location of an option of the oapi.v1 extension of the descriptor. The
+// path holds the names of the fields of the option messages and the indexes of repeated fields,
+// e.g. locateOption(method, "path_parameter", 1) for the second path parameter of the method. Files
+// compiled without source info are located by their name only and options without a location of
+// their own, like ones within an aggregate value, are located at the closest option or descriptor.
+func locateOption(desc protoreflect.Descriptor, path ...any) string {
+	file := desc.ParentFile()
+	if file == nil {
+		return ""
+	}
+
+	locations := file.SourceLocations()
+	if locations.Len() == 0 {
+		return file.Path()
+	}
+
+	descPath := locations.ByDescriptor(desc).Path
+	if _, ok := desc.(protoreflect.FileDescriptor); !ok && len(descPath) == 0 {
+		return file.Path()
+	}
+
+	fullPath := append(protoreflect.SourcePath{}, descPath...)
+	fullPath = append(fullPath, getOptionPath(desc, path)...)
+
+	for len(fullPath) >= len(descPath) {
+		location := locations.ByPath(fullPath)
+		if len(location.Path) > 0 || len(fullPath) == 0 {
+			return fmt.Sprintf("%s:%d:%d", file.Path(), location.StartLine+1, location.StartColumn+1)
+		}
+
+		fullPath = fullPath[:len(fullPath)-1]
+	}
+
+	return file.Path()
+}
+
+// getOptionPath returns the source path of the option below the descriptor. The path stops at the
+// first part that can't be resolved.
+func getOptionPath(desc protoreflect.Descriptor, path []any) protoreflect.SourcePath {
+	var descProto proto.Message
+	var extension protoreflect.ExtensionType
+
+	switch desc.(type) {
+	case protoreflect.FileDescriptor:
+		descProto, extension = &descriptorpb.FileDescriptorProto{}, oapiv1.E_File
+	case protoreflect.MessageDescriptor:
+		descProto, extension = &descriptorpb.DescriptorProto{}, oapiv1.E_Message
+	case protoreflect.FieldDescriptor:
+		descProto, extension = &descriptorpb.FieldDescriptorProto{}, oapiv1.E_Options
+	case protoreflect.OneofDescriptor:
+		descProto, extension = &descriptorpb.OneofDescriptorProto{}, oapiv1.E_Oneof
+	case protoreflect.ServiceDescriptor:
+		descProto, extension = &descriptorpb.ServiceDescriptorProto{}, oapiv1.E_Service
+	case protoreflect.MethodDescriptor:
+		descProto, extension = &descriptorpb.MethodDescriptorProto{}, oapiv1.E_Method
+	default:
+		return nil
+	}
+
+	if len(path) == 0 {
+		return nil
+	}
+
+	extensionType := extension.TypeDescriptor()
+
+	sourcePath := protoreflect.SourcePath{
+		int32(descProto.ProtoReflect().Descriptor().Fields().ByName("options").Number()),
+		int32(extensionType.Number()),
+	}
+
+	message := extensionType.Message()
+
+	for _, part := range path {
+		switch part := part.(type) {
+		case string:
+			if message == nil {
+				return sourcePath
+			}
+
+			field := message.Fields().ByName(protoreflect.Name(part))
+			if field == nil {
+				return sourcePath
+			}
+
+			sourcePath = append(sourcePath, int32(field.Number()))
+			message = field.Message()
+		case int:
+			sourcePath = append(sourcePath, int32(part))
+		}
+	}
+
+	return sourcePath
+}
+
+// locateOperation returns the location of the method the operation was generated from. Operations
+// of the base file have none.
+func (g *Generator) locateOperation(op *openapi3.Operation) string {
+	method, ok := g.operationSources[op]
+	if !ok {
+		return ""
+	}
+
+	return locate(method.Desc)
+}
+
+// report writes the diagnostic to stderr unless configured otherwise, which protoc shows along with
+// the error of the plugin. An error that isn't a diagnostic is reported without a location. The
+// same diagnostic is only reported once since messages and options can be built for several paths.
+func (g *Generator) report(err error) {
+	var d *diagnostic
+	if !errors.As(err, &d) {
+		d = &diagnostic{message: err.Error()}
+	}
+
+	message := d.Error()
+	if g.reported[message] {
+		return
+	}
+	g.reported[message] = true
+
+	out := g.config.Diagnostics
+	if out == nil {
+		out = os.Stderr
+	}
+
+	fmt.Fprintln(out, message)
+
+	if d.warning {
+		g.warnings++
+	} else {
+		g.errors++
+	}
+}
+
+// warn reports a warning that doesn't come from a proto element. Warnings don't fail the generation
+// unless strict is set.
+func (g *Generator) warn(format string, args ...any) {
+	g.warnAt("", format, args...)
+}
+
+// warnAt reports a warning at the location.
+func (g *Generator) warnAt(location, format string, args ...any) {
+	g.report(&diagnostic{
+		location: location,
+		message:  fmt.Sprintf(format, args...),
+		warning:  true,
+	})
+}
+
+// checkReported returns an error if any errors were reported, so generation stops after everything
+// that could be checked was.
+func (g *Generator) checkReported() error {
+	if g.errors == 0 {
+		return nil
+	}
+
+	if g.errors == 1 {
+		return errors.New("1 error reported")
+	}
+
+	return fmt.Errorf("%d errors reported", g.errors)
+}
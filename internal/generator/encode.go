@@ -0,0 +1,228 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaPatches holds the fields of the schemas that are changed for encoding so they can be
+// restored once the document is encoded. The openapi3 types can't write empty properties and write
+// maps sorted, so properties are set as an extension, which is written in place of the field.
+type schemaPatches map[*openapi3.Schema]patchedSchema
+
+// patchedSchema holds the original fields of a patched schema.
+type patchedSchema struct {
+	extensions map[string]any
+	properties openapi3.Schemas
+}
+
+// setProperties has the schema written with the properties in place of its own.
+func (p schemaPatches) setProperties(schema *openapi3.Schema, properties any) {
+	if _, ok := p[schema]; !ok {
+		p[schema] = patchedSchema{extensions: schema.Extensions, properties: schema.Properties}
+	}
+
+	extensions := make(map[string]any, len(schema.Extensions)+1)
+	for key, value := range schema.Extensions {
+		extensions[key] = value
+	}
+	extensions["properties"] = properties
+
+	schema.Extensions = extensions
+	schema.Properties = nil
+}
+
+// restore restores the original fields of the patched schemas.
+func (p schemaPatches) restore() {
+	for schema, patched := range p {
+		schema.Extensions = patched.extensions
+		schema.Properties = patched.properties
+	}
+}
+
+// addEmptyProperties gives the empty schemas of the JSON request bodies and responses of the
+// operations empty properties, so they're written as an empty object rather than as {}, which some
+// clients read as any value. JSON content is the configured content type and JSON media types.
+func (g *Generator) addEmptyProperties(doc *openapi3.T, patches schemaPatches) {
+	for _, paths := range []openapi3.Paths{doc.Paths, g.webhooks} {
+		for _, pathItem := range paths {
+			for _, op := range pathItem.Operations() {
+				if op.RequestBody != nil && op.RequestBody.Ref == "" && op.RequestBody.Value != nil {
+					g.addEmptyContentProperties(op.RequestBody.Value.Content, patches)
+				}
+
+				for _, response := range op.Responses {
+					if response.Ref == "" && response.Value != nil {
+						g.addEmptyContentProperties(response.Value.Content, patches)
+					}
+				}
+			}
+		}
+	}
+}
+
+// addEmptyContentProperties gives the empty schemas of the JSON content empty properties.
+func (g *Generator) addEmptyContentProperties(content openapi3.Content, patches schemaPatches) {
+	for contentType, mediaType := range content {
+		if !g.isJSONContentType(contentType) {
+			continue
+		}
+
+		if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Ref != "" || mediaType.Schema.Value == nil {
+			continue
+		}
+
+		if isEmptySchema(mediaType.Schema.Value) {
+			patches.setProperties(mediaType.Schema.Value, map[string]any{})
+		}
+	}
+}
+
+// isJSONContentType returns whether the content type is the configured one or a JSON media type.
+func (g *Generator) isJSONContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+
+	return contentType == *g.config.ContentType || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isEmptySchema returns whether the schema is written as {}.
+func isEmptySchema(schema *openapi3.Schema) bool {
+	if len(schema.Extensions) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0 ||
+		len(schema.Enum) > 0 || len(schema.Required) > 0 || len(schema.Properties) > 0 {
+		return false
+	}
+
+	// Empty lists and maps aren't written, but they aren't zero values either.
+	s := *schema
+	s.Extensions, s.OneOf, s.AnyOf, s.AllOf, s.Enum, s.Required, s.Properties = nil, nil, nil, nil, nil, nil, nil
+
+	return reflect.ValueOf(s).IsZero()
+}
+
+// encodeDocument encodes the document to the node tree it's written from. The openapi3 types only
+// marshal to JSON and do so through maps, so the document is marshaled once and parsed into a node
+// tree, which is ordered and has the overlays applied. The tree is also what validateOutput checks.
+func (g *Generator) encodeDocument(doc any) (*yaml.Node, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	err = yaml.Unmarshal(data, &root)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is parsed as flow mappings of quoted strings, which YAML doesn't need.
+	resetNodeStyles(&root)
+	g.orderDocument(&root)
+
+	err = g.applyOverlays(&root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &root, nil
+}
+
+// writeDocument writes the node tree of the document in the output format.
+func (g *Generator) writeDocument(root *yaml.Node) ([]byte, error) {
+	buffer := bytes.Buffer{}
+
+	if *g.config.JSONOutput {
+		err := writeJSONNode(&buffer, root)
+		return buffer.Bytes(), err
+	}
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(root)
+	return buffer.Bytes(), err
+}
+
+// resetNodeStyles resets the styles of the node and the nodes under it so they're written in the
+// default style.
+func resetNodeStyles(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		resetNodeStyles(child)
+	}
+}
+
+// writeJSONNode writes the node as JSON, keeping the order of the keys of its mappings.
+func writeJSONNode(buffer *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			err := writeJSONNode(buffer, child)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		buffer.WriteByte('{')
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+
+			buffer.Write(key)
+			buffer.WriteByte(':')
+
+			err = writeJSONNode(buffer, node.Content[i+1])
+			if err != nil {
+				return err
+			}
+		}
+
+		buffer.WriteByte('}')
+	case yaml.SequenceNode:
+		buffer.WriteByte('[')
+
+		for i, child := range node.Content {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+
+			err := writeJSONNode(buffer, child)
+			if err != nil {
+				return err
+			}
+		}
+
+		buffer.WriteByte(']')
+	case yaml.AliasNode:
+		return writeJSONNode(buffer, node.Alias)
+	case yaml.ScalarNode:
+		var value any
+
+		err := node.Decode(&value)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		buffer.Write(data)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// addEnumSchema adds the enum as a schema to the OAPI doc if it isn't already there and returns the
+// reference to it.
+func (g *Generator) addEnumSchema(doc *openapi3.T, enum *protogen.Enum) string {
+	name := string(enum.Desc.FullName())
+
+	if !schemaExists(doc, name) {
+		addSchema(doc, name, g.newEnumSchema(enum).NewRef())
+	}
+
+	return newSchemaRef(name)
+}
+
+// newEnumSchema returns a new OAPI schema for the enum. Values are represented by their names like
+// protojson does unless the config is set to use enum numbers. Value comments are added to the
+// "x-enumDescriptions" extension.
+func (g *Generator) newEnumSchema(enum *protogen.Enum) *openapi3.Schema {
+	useNumbers := *g.config.UseEnumNumbers
+
+	schema := &openapi3.Schema{
+		Type:        openapi3.TypeString,
+		Description: g.parseComments(enum.Comments.Leading).Description,
+		Enum:        make([]any, 0, len(enum.Values)),
+	}
+
+	if useNumbers {
+		schema.Type = openapi3.TypeInteger
+		schema.Format = "int32"
+	}
+
+	descriptions := make(map[string]string)
+	seen := make(map[int32]struct{})
+
+	for _, value := range enum.Values {
+		key := string(value.Desc.Name())
+
+		if useNumbers {
+			number := int32(value.Desc.Number())
+			key = strconv.Itoa(int(number))
+
+			// Aliased values share a number, so they're only listed once.
+			if _, ok := seen[number]; ok {
+				continue
+			}
+			seen[number] = struct{}{}
+
+			schema.Enum = append(schema.Enum, number)
+		} else {
+			schema.Enum = append(schema.Enum, key)
+		}
+
+		description := strings.TrimSpace(g.parseComments(value.Comments.Leading).Description)
+		if description != "" {
+			descriptions[key] = description
+		}
+	}
+
+	if len(descriptions) > 0 {
+		schema.Extensions = map[string]any{
+			"x-enumDescriptions": descriptions,
+		}
+	}
+
+	return schema
+}
+
+// wrapEnumRefs keeps the annotations of enum fields on a schema with the reference to the enum as
+// its only subschema, since OpenAPI 3.0 ignores anything next to a reference. OpenAPI 3.1 keeps
+// them next to the reference instead.
+func wrapEnumRefs(doc *openapi3.T, webhooks openapi3.Paths) {
+	walkDocumentSchemas(doc, webhooks, func(_ string, schemaRef *openapi3.SchemaRef) {
+		walkSchemaRef(schemaRef, func(schemaRef *openapi3.SchemaRef) {
+			schema := schemaRef.Value
+			if schemaRef.Ref == "" || schema == nil || (schema.Description == "" && !schema.Deprecated && !schema.Nullable) {
+				return
+			}
+
+			if enum := getRefSchema(doc, schemaRef.Ref); enum == nil || len(enum.Enum) == 0 {
+				return
+			}
+
+			schemaRef.Value = &openapi3.Schema{
+				Description: schema.Description,
+				Deprecated:  schema.Deprecated,
+				Nullable:    schema.Nullable,
+				AllOf:       openapi3.SchemaRefs{{Ref: schemaRef.Ref}},
+			}
+			schemaRef.Ref = ""
+		})
+	})
+}
@@ -1,33 +1,66 @@
 package generator
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/stretchr/objx"
 	oapiv1 "github.com/technicallyjosh/protoc-gen-openapi/api/oapi/v1"
 	"github.com/technicallyjosh/protoc-gen-openapi/internal/generator/util"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
-	"gopkg.in/yaml.v3"
 )
 
 // Config holds the configuration for the generator.
 type Config struct {
-	ContentType     *string
-	DefaultResponse *string
-	Description     *string
-	Filename        *string
-	Host            *string
-	Ignore          *string
-	Include         *string
-	JSONOutput      *bool
-	Title           *string
-	UseJSONNames    *bool
-	Version         *string
+	BaseFile          *string
+	ComponentStrategy *string
+	ContentType       *string
+	DefaultResponse   *string
+	Description       *string
+	Filename          *string
+	Host              *string
+	Ignore            *string
+	Include           *string
+	InputQuery        *bool
+	JSONOutput        *bool
+	OpenAPIVersion    *string
+	OutputFormat      *string
+	OutputMode        *string
+	Overlays          *StringList
+	PathOrder         *string
+	Routing           *string
+	SharedComponents  *bool
+	Strict            *bool
+	Title             *string
+	UseEnumNumbers    *bool
+	UseJSONNames      *bool
+	Version           *string
+
+	// The rest aren't plugin options but are set when the generator is embedded.
+
+	// Diagnostics is where errors and warnings are reported. Defaults to stderr.
+	Diagnostics io.Writer
+	// OperationHook is called with each built operation before it's added to the document.
+	OperationHook func(method *protogen.Method, op *openapi3.Operation) error
+	// SchemaHook is called with the schema of each message once it's built. Messages built inline
+	// are passed each time they're built.
+	SchemaHook func(message *protogen.Message, schema *openapi3.Schema) error
+}
+
+// StringList is a flag that can be set multiple times.
+type StringList []string
+
+// String returns the values joined by commas.
+func (l *StringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set adds the value to the list.
+func (l *StringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
 }
 
 // Generator is an instance that parses the given folder and its Protobuf files into OAPI.
@@ -35,201 +68,205 @@ type Generator struct {
 	config   Config
 	plugin   *protogen.Plugin
 	packages []string
+	// messages holds all messages by full name for reference whenever we need to look for a message
+	// to build out.
+	messages messageMap
+	// building holds the messages currently being built by full name so recursive messages can be
+	// detected.
+	building map[string]int
+	// rpcMessages holds the full names of messages used as the input or output of an RPC.
+	rpcMessages map[string]bool
+	// securitySchemes holds the declarations of the security schemes of all files by name.
+	securitySchemes map[string][]*securitySchemeDeclaration
+	// webhooks holds the operations of methods added as webhooks by name.
+	webhooks openapi3.Paths
+	// paths holds the paths and webhooks of the document in the order they were added.
+	paths []string
+	// propertyOrders holds the property names of message schemas in the order of their fields.
+	propertyOrders map[*openapi3.Schema][]string
+	// schemaSources holds the messages of the component schemas by name for validation errors.
+	schemaSources map[string]*protogen.Message
+	// operationSources holds the methods the operations were generated from for validation errors.
+	operationSources map[*openapi3.Operation]*protogen.Method
+	// reported holds the diagnostics that were reported so each is only reported once.
+	reported map[string]bool
+	// errors is the number of errors reported.
+	errors int
+	// warnings is the number of warnings reported, which fail the generation when strict is set.
+	warnings int
 }
 
 // New creates and returns a new Generator instance.
 func New(plugin *protogen.Plugin, conf Config) *Generator {
 	return &Generator{
-		config:   conf,
-		plugin:   plugin,
-		packages: make([]string, 0),
+		config:           conf,
+		plugin:           plugin,
+		packages:         make([]string, 0),
+		messages:         make(messageMap),
+		building:         make(map[string]int),
+		rpcMessages:      make(map[string]bool),
+		webhooks:         make(openapi3.Paths),
+		propertyOrders:   make(map[*openapi3.Schema][]string),
+		schemaSources:    make(map[string]*protogen.Message),
+		operationSources: make(map[*openapi3.Operation]*protogen.Method),
+		reported:         make(map[string]bool),
 	}
 }
 
-// Run is the entrypoint method to generate OAPI from all Protobuf files. It builds the document and
-// then generates the OAPI file.
+// Run is the entrypoint method to generate OAPI from all Protobuf files. It builds the documents of
+// the output mode and then generates an OAPI file for each.
 func (g *Generator) Run() error {
-	useJSON := *g.config.JSONOutput
-
-	doc, err := g.buildDocument()
-	if err != nil {
-		return err
-	}
-
-	filename := *g.config.Filename + ".yaml"
-
-	fileBuffer := bytes.Buffer{}
-	jsonBytes, err := doc.MarshalJSON()
+	err := g.validateConfig()
 	if err != nil {
 		return err
 	}
 
-	if useJSON {
-		filename = *g.config.Filename + ".json"
-		fileBuffer.Write(jsonBytes)
-	} else {
-		// Extra hops to get JSON to YAML.
-		var i any
-		err = json.Unmarshal(jsonBytes, &i)
+	for _, o := range g.buildOutputs(g.getFiles()) {
+		doc, err := g.buildDocument(o)
 		if err != nil {
 			return err
 		}
 
-		encoder := yaml.NewEncoder(&fileBuffer)
-		encoder.SetIndent(2)
-
-		err = encoder.Encode(i)
+		err = g.generateFile(o.name+g.getExtension(), doc)
 		if err != nil {
 			return err
 		}
 	}
 
-	fileBytes := fileBuffer.Bytes()
-
-	outFile := g.plugin.NewGeneratedFile(filename, "")
+	return g.checkStrict()
+}
 
-	patchedBytes, err := g.patchEmptySchemas(fileBytes)
+// Build builds the document of the single output mode without generating any files, for embedding
+// the generator. The overlays and the output format and version aren't applied since they only
+// apply to the generated files.
+func (g *Generator) Build() (*openapi3.T, error) {
+	err := g.validateConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	patchedBytes, err = g.patchRemovedSecurity(patchedBytes)
+	doc, err := g.buildDocument(&output{name: *g.config.Filename})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = outFile.Write(patchedBytes)
-	return err
+	return doc, g.checkStrict()
 }
 
-func (g *Generator) patchRemovedSecurity(fileBytes []byte) ([]byte, error) {
-	data := make(map[string]any)
+// checkStrict returns an error if strict is set and any warnings were reported.
+func (g *Generator) checkStrict() error {
+	if *g.config.Strict && g.warnings > 0 {
+		return fmt.Errorf("strict is set and warnings were reported: %d", g.warnings)
+	}
 
-	useJSON := *g.config.JSONOutput
+	return nil
+}
 
-	var err error
-	if useJSON {
-		err = json.Unmarshal(fileBytes, &data)
-	} else {
-		err = yaml.Unmarshal(fileBytes, &data)
-	}
-	if err != nil {
-		return nil, err
+// getExtension returns the extension of the generated files.
+func (g *Generator) getExtension() string {
+	if *g.config.JSONOutput {
+		return ".json"
 	}
 
-	jsonBytes, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
+	return ".yaml"
+}
+
+// generateFile converts the document to the output format and generates it as the file.
+func (g *Generator) generateFile(filename string, doc *openapi3.T) error {
+	// The schemas are patched for encoding only, so they're restored even if it fails.
+	patches := make(schemaPatches)
+	defer patches.restore()
+
+	var out any = doc
+	if *g.config.OutputFormat == outputFormatSwagger2 {
+		var err error
+		out, err = g.convertToSwagger2(doc)
+		if err != nil {
+			return err
+		}
+	} else {
+		g.addEmptyProperties(doc, patches)
 	}
 
-	m, err := objx.FromJSON(string(jsonBytes))
+	g.orderProperties(doc, patches)
+
+	root, err := g.encodeDocument(out)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	for pathKey := range m.Get("paths").ObjxMap() {
-		pathPath := fmt.Sprintf("paths[%s]", pathKey)
-
-		for methodKey := range m.Get(pathPath).ObjxMap() {
-			methodPath := fmt.Sprintf("%s[%s]", pathPath, methodKey)
-			securityPath := fmt.Sprintf("%s.security", methodPath)
-			security := m.Get(securityPath)
-
-			if security.IsObjxMapSlice() && len(security.ObjxMapSlice()) == 1 {
-				// We need to look for single in array and with the name of
-				// "___remove"
-			outer:
-				for _, obj := range security.ObjxMapSlice() {
-					for key := range obj {
-						if key == "___remove" {
-							m.Set(securityPath, make([]string, 0))
-							break outer
-						}
-					}
-				}
-			}
-		}
+	err = g.validateOutput(doc, root)
+	if err != nil {
+		return err
 	}
 
-	if useJSON {
-		return json.Marshal(m)
+	fileBytes, err := g.writeDocument(root)
+	if err != nil {
+		return err
 	}
 
-	buffer := bytes.Buffer{}
-	encoder := yaml.NewEncoder(&buffer)
-	encoder.SetIndent(2)
-
-	err = encoder.Encode(m)
-	return buffer.Bytes(), err
+	_, err = g.plugin.NewGeneratedFile(filename, "").Write(fileBytes)
+	return err
 }
 
-// patchEmptySchemas finds any schemas that are empty and updates them to have
-// an empty `Properties` node.
-func (g *Generator) patchEmptySchemas(fileBytes []byte) ([]byte, error) {
-	data := make(map[string]any)
-
-	useJSON := *g.config.JSONOutput
+// validateConfig returns an error if any of the options with a set of values is invalid.
+func (g *Generator) validateConfig() error {
+	err := g.validateOpenAPIVersion()
+	if err != nil {
+		return err
+	}
 
-	var err error
-	if useJSON {
-		err = json.Unmarshal(fileBytes, &data)
-	} else {
-		err = yaml.Unmarshal(fileBytes, &data)
+	err = g.validateComponentStrategy()
+	if err != nil {
+		return err
 	}
+
+	err = g.validateOutputFormat()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	jsonBytes, err := json.Marshal(data)
+	err = g.validateOutputMode()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	m, err := objx.FromJSON(string(jsonBytes))
+	err = g.validatePathOrder()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	for pathKey := range m.Get("paths").ObjxMap() {
-		pathPath := fmt.Sprintf("paths[%s]", pathKey)
+	return g.validateRouting()
+}
 
-		for methodKey := range m.Get(pathPath).ObjxMap() {
-			methodPath := fmt.Sprintf("%s[%s]", pathPath, methodKey)
+// getFiles returns the files to generate from after the include and ignore options are applied.
+func (g *Generator) getFiles() []*protogen.File {
+	included := strings.Split(*g.config.Include, "|")
+	ignored := strings.Split(*g.config.Ignore, "|")
 
-			schemaKey := fmt.Sprintf("%s.requestBody.content.application/json.schema", methodPath)
-			schema := m.Get(schemaKey)
+	files := g.plugin.Files
 
-			if schema.IsObjxMap() && len(schema.ObjxMap()) == 0 {
-				schema.ObjxMap().Set("properties", make(map[string]any))
-			}
+	if len(included) > 0 {
+		files = filterIncludedFiles(g.plugin.Files, included)
+	}
 
-			for resKey := range m.Get(methodPath + ".responses").ObjxMap() {
-				schemaKey := fmt.Sprintf("%s.responses.%s.content.application/json.schema", methodPath, resKey)
-				schema := m.Get(schemaKey)
-				if schema.IsObjxMap() && len(schema.ObjxMap()) == 0 {
-					schema.ObjxMap().Set("properties", make(map[string]any))
-				}
-			}
+	if len(ignored) > 0 {
+		if len(files) == 0 {
+			files = g.plugin.Files
 		}
-	}
 
-	if useJSON {
-		return json.Marshal(m)
+		files = filterIgnoredFiles(files, ignored)
 	}
 
-	buffer := bytes.Buffer{}
-	encoder := yaml.NewEncoder(&buffer)
-	encoder.SetIndent(2)
-
-	err = encoder.Encode(m)
-	return buffer.Bytes(), err
+	return files
 }
 
-// buildDocument builds out the base of the OAPI document with some defaults.
-func (g *Generator) buildDocument() (*openapi3.T, error) {
+// buildDocument builds out the OAPI document of the output with some defaults. Documents of a
+// single output mode only hold the schemas used by their paths.
+func (g *Generator) buildDocument(o *output) (*openapi3.T, error) {
 	doc := &openapi3.T{
 		Extensions: make(map[string]any),
-		OpenAPI:    "3.0.3",
+		OpenAPI:    g.getOpenAPIVersion(),
 		Components: &openapi3.Components{
 			SecuritySchemes: make(openapi3.SecuritySchemes),
 			Schemas:         make(openapi3.Schemas),
@@ -247,31 +284,27 @@ func (g *Generator) buildDocument() (*openapi3.T, error) {
 		Tags:     make(openapi3.Tags, 0),
 	}
 
-	included := strings.Split(*g.config.Include, "|")
-	ignored := strings.Split(*g.config.Ignore, "|")
+	// Each document is built from scratch.
+	g.packages = make([]string, 0)
+	g.webhooks = make(openapi3.Paths)
+	g.paths = nil
+	g.propertyOrders = make(map[*openapi3.Schema][]string)
+	g.schemaSources = make(map[string]*protogen.Message)
+	g.operationSources = make(map[*openapi3.Operation]*protogen.Method)
 
-	files := g.plugin.Files
+	files := g.getFiles()
 
-	if len(included) > 0 {
-		files = filterIncludedFiles(g.plugin.Files, included)
+	for _, file := range files {
+		g.buildRPCMessages(file.Services)
 	}
 
-	if len(ignored) > 0 {
-		if len(files) == 0 {
-			files = g.plugin.Files
-		}
-
-		files = filterIgnoredFiles(files, ignored)
-	}
+	g.buildSecuritySchemes()
 
 	for _, file := range files {
 		g.buildMessageMap(file.Messages)
 
 		// We use the package name for fully qualified schema names.
-		err := g.addSchemasToDoc(doc, file.Messages)
-		if err != nil {
-			return nil, err
-		}
+		g.addSchemasToDoc(doc, file.Messages)
 
 		// Capture all package names for later use.
 		g.packages = append(g.packages, file.Proto.GetPackage())
@@ -283,25 +316,66 @@ func (g *Generator) buildDocument() (*openapi3.T, error) {
 	}
 
 	for _, file := range files {
+		if !o.hasFile(file) {
+			continue
+		}
+
 		// Add servers even if there isn't a service. (File-based)
 		err = addFileServersToDoc(doc, file)
 		if err != nil {
-			return nil, err
+			g.report(locatedAt(file.Desc, err))
 		}
 
-		err = addFileSecurityToDoc(doc, file)
-		if err != nil {
-			return nil, err
+		g.addFileSecurityToDoc(doc, file)
+
+		g.addPathsToDoc(doc, o.filterServices(file.Services))
+	}
+
+	// Everything is built before stopping so all errors are reported at once.
+	err = g.checkReported()
+	if err != nil {
+		return nil, err
+	}
+
+	util.UniqueServers(doc)
+	util.UniqueTags(doc)
+
+	if *g.config.OpenAPIVersion == openAPIVersion30 {
+		wrapEnumRefs(doc, g.webhooks)
+	}
+
+	if len(g.webhooks) > 0 {
+		doc.Extensions["webhooks"] = g.webhooks
+	}
+
+	if o.services != nil {
+		if *g.config.SharedComponents {
+			g.useSharedComponents(doc, o)
+		} else {
+			g.pruneSchemas(doc)
 		}
+	}
 
-		err = g.addPathsToDoc(doc, file.Services)
+	if *g.config.BaseFile != "" && !o.components {
+		doc, err = g.mergeBaseFile(doc)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	util.UniqueServers(doc)
-	util.UniqueTags(doc)
+	if doc.Info == nil || doc.Info.Title == "" {
+		// The title has always been optional here, so it's only a warning.
+		g.warn("title isn't set, which OpenAPI requires")
+	}
+
+	err = g.validateDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if *g.config.OpenAPIVersion == openAPIVersion31 {
+		convertToOpenAPI31(doc, g.webhooks)
+	}
 
 	return doc, nil
 }
@@ -333,41 +407,6 @@ func addFileServersToDoc(doc *openapi3.T, file *protogen.File) error {
 	return nil
 }
 
-func addFileSecurityToDoc(doc *openapi3.T, file *protogen.File) error {
-	extFile := proto.GetExtension(file.Desc.Options(), oapiv1.E_File)
-
-	if extFile != nil && extFile != oapiv1.E_File.InterfaceOf(oapiv1.E_File.Zero()) {
-		fileOptions := extFile.(*oapiv1.FileOptions)
-
-		for _, scheme := range fileOptions.SecuritySchemes {
-			doc.Components.SecuritySchemes[scheme.Name] = &openapi3.SecuritySchemeRef{
-				Value: &openapi3.SecurityScheme{
-					Type:             scheme.Scheme.Type,
-					Description:      "",
-					Name:             scheme.Scheme.Name,
-					In:               scheme.Scheme.In,
-					Scheme:           scheme.Scheme.Scheme,
-					BearerFormat:     scheme.Scheme.BearerFormat,
-					OpenIdConnectUrl: scheme.Scheme.OpenIdConnectUrl,
-					// TODO: Implement flows
-					Flows: nil,
-				},
-			}
-		}
-
-		requirements := make([]openapi3.SecurityRequirement, 0)
-		for _, sec := range fileOptions.Security {
-			requirements = append(requirements, openapi3.SecurityRequirement{
-				sec.Name: sec.Scopes,
-			})
-		}
-
-		doc.Security = requirements
-	}
-
-	return nil
-}
-
 func filterIgnoredFiles(allFiles []*protogen.File, ignored []string) []*protogen.File {
 	files := make([]*protogen.File, 0)
 
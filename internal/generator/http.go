@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	oapiv1 "github.com/technicallyjosh/protoc-gen-openapi/api/oapi/v1"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+// httpBinding is an HTTP method and path that an RPC is bound to along with how its input and
+// output are mapped.
+type httpBinding struct {
+	method string
+	path   string
+	// body is the input field sent as the request body. "*" is the whole input and empty is none.
+	body string
+	// query is whether the input fields that aren't bound to the path or body are query parameters.
+	query bool
+	// responseBody is the output field sent as the response body. Empty is the whole output.
+	responseBody string
+}
+
+// newMethodBinding returns the binding defined by the method options.
+func (g *Generator) newMethodBinding(method *protogen.Method, methodOptions *oapiv1.MethodOptions) (*httpBinding, error) {
+	binding := new(httpBinding)
+
+	switch m := methodOptions.Method.(type) {
+	case *oapiv1.MethodOptions_Get:
+		binding.method = http.MethodGet
+		binding.path = m.Get
+	case *oapiv1.MethodOptions_Put:
+		binding.method = http.MethodPut
+		binding.path = m.Put
+	case *oapiv1.MethodOptions_Post:
+		binding.method = http.MethodPost
+		binding.path = m.Post
+	case *oapiv1.MethodOptions_Delete:
+		binding.method = http.MethodDelete
+		binding.path = m.Delete
+	case *oapiv1.MethodOptions_Patch:
+		binding.method = http.MethodPatch
+		binding.path = m.Patch
+	default:
+		return nil, fmt.Errorf("method '%s' is missing a method", method.Desc.FullName())
+	}
+
+	// The method option takes precedence over the config for expanding the input into the query.
+	inputQuery := *g.config.InputQuery
+	if methodOptions.InputQuery != nil {
+		inputQuery = *methodOptions.InputQuery
+	}
+
+	binding.query = inputQuery && (binding.method == http.MethodGet || binding.method == http.MethodDelete)
+
+	if binding.method != http.MethodGet && !binding.query {
+		binding.body = "*"
+	}
+
+	return binding, nil
+}
+
+// getHTTPRule returns the google.api.http rule of the method or nil if not defined.
+func getHTTPRule(method *protogen.Method) *annotations.HttpRule {
+	extHTTP := proto.GetExtension(method.Desc.Options(), annotations.E_Http)
+	if extHTTP != nil && extHTTP != annotations.E_Http.InterfaceOf(annotations.E_Http.Zero()) {
+		return extHTTP.(*annotations.HttpRule)
+	}
+
+	return nil
+}
+
+// newRuleBindings returns the bindings for a google.api.http rule including its additional
+// bindings.
+func newRuleBindings(method *protogen.Method, rule *annotations.HttpRule) ([]*httpBinding, error) {
+	rules := append([]*annotations.HttpRule{rule}, rule.AdditionalBindings...)
+	bindings := make([]*httpBinding, 0, len(rules))
+
+	for _, r := range rules {
+		binding := &httpBinding{
+			body:         r.Body,
+			query:        r.Body != "*",
+			responseBody: r.ResponseBody,
+		}
+
+		switch pattern := r.Pattern.(type) {
+		case *annotations.HttpRule_Get:
+			binding.method = http.MethodGet
+			binding.path = pattern.Get
+		case *annotations.HttpRule_Put:
+			binding.method = http.MethodPut
+			binding.path = pattern.Put
+		case *annotations.HttpRule_Post:
+			binding.method = http.MethodPost
+			binding.path = pattern.Post
+		case *annotations.HttpRule_Delete:
+			binding.method = http.MethodDelete
+			binding.path = pattern.Delete
+		case *annotations.HttpRule_Patch:
+			binding.method = http.MethodPatch
+			binding.path = pattern.Patch
+		case *annotations.HttpRule_Custom:
+			binding.method = strings.ToUpper(pattern.Custom.GetKind())
+			binding.path = pattern.Custom.GetPath()
+
+			switch binding.method {
+			case http.MethodHead, http.MethodOptions, http.MethodTrace:
+			default:
+				return nil, fmt.Errorf("method '%s' has unsupported custom HTTP method '%s'", method.Desc.FullName(), pattern.Custom.GetKind())
+			}
+		default:
+			return nil, fmt.Errorf("method '%s' is missing a pattern in its google.api.http rule", method.Desc.FullName())
+		}
+
+		bindings = append(bindings, binding)
+	}
+
+	return bindings, nil
+}
@@ -1,16 +1,27 @@
 package generator
 
 import (
+	"fmt"
+	"strings"
+
+	oapiv1 "github.com/technicallyjosh/protoc-gen-openapi/api/oapi/v1"
 	"github.com/technicallyjosh/protoc-gen-openapi/internal/generator/util"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
 )
 
-var (
-	// allMessages holds all messages with their full paths for reference whenever we need to look
-	// for a message to build out.
-	allMessages = make(messageMap)
+const (
+	// componentStrategySuffix adds all messages that don't end with Request or Response to the
+	// component schemas.
+	componentStrategySuffix = "suffix"
+	// componentStrategyRPC adds all messages that aren't used as the input or output of an RPC to
+	// the component schemas.
+	componentStrategyRPC = "rpc"
+	// componentStrategyAll adds all messages to the component schemas.
+	componentStrategyAll = "all"
 )
 
+// messageMap holds messages by their full names.
 type messageMap map[string]*protogen.Message
 
 // Set adds the specified message to the map.
@@ -31,10 +42,86 @@ func (m messageMap) Get(key string) *protogen.Message {
 // buildMessageMap recursively adds messages by full path to a map for usage later.
 func (g *Generator) buildMessageMap(messages []*protogen.Message) {
 	for _, message := range messages {
-		allMessages.Set(message)
+		g.messages.Set(message)
 
 		if len(message.Messages) > 0 {
 			g.buildMessageMap(message.Messages)
 		}
 	}
 }
+
+// buildRPCMessages adds the full names of all messages used as the input or output of an RPC.
+func (g *Generator) buildRPCMessages(services []*protogen.Service) {
+	for _, service := range services {
+		for _, method := range service.Methods {
+			g.rpcMessages[util.FullName(method.Input)] = true
+			g.rpcMessages[util.FullName(method.Output)] = true
+		}
+	}
+}
+
+// validateComponentStrategy returns an error if the configured component strategy isn't valid.
+func (g *Generator) validateComponentStrategy() error {
+	switch *g.config.ComponentStrategy {
+	case componentStrategySuffix, componentStrategyRPC, componentStrategyAll:
+		return nil
+	default:
+		return fmt.Errorf("invalid component_strategy '%s'", *g.config.ComponentStrategy)
+	}
+}
+
+// getMessageOptions returns the message options or empty ones if not defined.
+func getMessageOptions(message *protogen.Message) *oapiv1.MessageOptions {
+	extMessage := proto.GetExtension(message.Desc.Options(), oapiv1.E_Message)
+	if extMessage != nil && extMessage != oapiv1.E_Message.InterfaceOf(oapiv1.E_Message.Zero()) {
+		return extMessage.(*oapiv1.MessageOptions)
+	}
+
+	return new(oapiv1.MessageOptions)
+}
+
+// schemaName returns the name of the component schema for the message.
+func schemaName(message *protogen.Message) string {
+	name := strings.TrimSpace(getMessageOptions(message).Name)
+	if name != "" {
+		return name
+	}
+
+	return util.FullName(message)
+}
+
+// isComponent returns whether the message belongs in the component schemas or is built inline. The
+// message option takes precedence over the configured strategy. Nested messages are only
+// components when their option says so.
+func (g *Generator) isComponent(message *protogen.Message) bool {
+	messageOptions := getMessageOptions(message)
+	if messageOptions.Component != nil {
+		return *messageOptions.Component
+	}
+
+	if message.Desc.IsMapEntry() || message.Desc.Parent() != message.Desc.ParentFile() {
+		return false
+	}
+
+	switch *g.config.ComponentStrategy {
+	case componentStrategyRPC:
+		return !g.rpcMessages[util.FullName(message)]
+	case componentStrategyAll:
+		return true
+	default:
+		return !util.IsRequestMessage(message) && !util.IsResponseMessage(message)
+	}
+}
+
+// isBodyComponent returns whether the message is referenced when it's the request or response body
+// of an operation. Bodies have always been built inline with the suffix strategy, so they're only
+// referenced there when their option makes them components.
+func (g *Generator) isBodyComponent(message *protogen.Message) bool {
+	if *g.config.ComponentStrategy != componentStrategySuffix {
+		return g.isComponent(message)
+	}
+
+	component := getMessageOptions(message).Component
+
+	return component != nil && *component
+}
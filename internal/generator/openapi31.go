@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// openAPIVersion30 generates an OpenAPI 3.0 document.
+	openAPIVersion30 = "3.0"
+	// openAPIVersion31 generates an OpenAPI 3.1 document.
+	openAPIVersion31 = "3.1"
+)
+
+// validateOpenAPIVersion returns an error if the configured OpenAPI version isn't supported.
+func (g *Generator) validateOpenAPIVersion() error {
+	switch *g.config.OpenAPIVersion {
+	case openAPIVersion30, openAPIVersion31:
+		return nil
+	default:
+		return fmt.Errorf("invalid openapi_version '%s'", *g.config.OpenAPIVersion)
+	}
+}
+
+// getOpenAPIVersion returns the full version of the document for the configured version.
+func (g *Generator) getOpenAPIVersion() string {
+	if *g.config.OpenAPIVersion == openAPIVersion31 {
+		return "3.1.0"
+	}
+
+	return "3.0.3"
+}
+
+// schemaConverter converts the schemas of a document that are built as OpenAPI 3.0 to JSON Schema
+// 2020-12 as used by OpenAPI 3.1. The keywords that openapi3.Schema can't represent are set through
+// its extensions since those are marshaled along with the other keywords.
+type schemaConverter struct {
+	converted map[*openapi3.Schema]bool
+}
+
+// convertToOpenAPI31 converts all schemas of the document including the ones of webhooks.
+func convertToOpenAPI31(doc *openapi3.T, webhooks openapi3.Paths) {
+	c := &schemaConverter{
+		converted: make(map[*openapi3.Schema]bool),
+	}
+
+	walkDocumentSchemas(doc, webhooks, func(_ string, schemaRef *openapi3.SchemaRef) {
+		c.convertSchemaRef(schemaRef)
+	})
+}
+
+// convertSchemaRef converts the schema and all of its subschemas in place.
+func (c *schemaConverter) convertSchemaRef(schemaRef *openapi3.SchemaRef) {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return
+	}
+
+	schema := schemaRef.Value
+
+	if schemaRef.Ref != "" {
+		// References can have siblings in 3.1, so the annotations of the field are kept.
+		siblings := &openapi3.Schema{
+			Extensions:  map[string]any{"$ref": schemaRef.Ref},
+			Description: schema.Description,
+			Deprecated:  schema.Deprecated,
+		}
+
+		if schema.Nullable {
+			// A reference can't be given another type, so null is allowed as an alternative.
+			siblings.Extensions = make(map[string]any)
+			siblings.AnyOf = openapi3.SchemaRefs{
+				{Ref: schemaRef.Ref},
+				{Value: &openapi3.Schema{Type: "null"}},
+			}
+		}
+
+		schemaRef.Ref = ""
+		schemaRef.Value = siblings
+		c.converted[siblings] = true
+
+		return
+	}
+
+	if c.converted[schema] {
+		return
+	}
+	c.converted[schema] = true
+
+	if schema.Extensions == nil {
+		schema.Extensions = make(map[string]any)
+	}
+
+	if schema.Nullable {
+		// Schemas without a type already allow null.
+		if schema.Type != "" {
+			schema.Extensions["type"] = []any{schema.Type, "null"}
+			schema.Type = ""
+		}
+		schema.Nullable = false
+	}
+
+	if schema.Example != nil {
+		schema.Extensions["examples"] = []any{schema.Example}
+		schema.Example = nil
+	}
+
+	if propertyNames, ok := schema.Extensions["x-propertyNames"]; ok {
+		schema.Extensions["propertyNames"] = propertyNames
+		delete(schema.Extensions, "x-propertyNames")
+	}
+
+	if schema.ExclusiveMin && schema.Min != nil {
+		schema.Extensions["exclusiveMinimum"] = *schema.Min
+		schema.ExclusiveMin = false
+		schema.Min = nil
+	}
+
+	if schema.ExclusiveMax && schema.Max != nil {
+		schema.Extensions["exclusiveMaximum"] = *schema.Max
+		schema.ExclusiveMax = false
+		schema.Max = nil
+	}
+
+	for _, propertyRef := range schema.Properties {
+		c.convertSchemaRef(propertyRef)
+	}
+
+	for _, schemaRefs := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf, schema.AllOf} {
+		for _, subSchemaRef := range schemaRefs {
+			c.convertSchemaRef(subSchemaRef)
+		}
+	}
+
+	c.convertSchemaRef(schema.Items)
+	c.convertSchemaRef(schema.Not)
+	c.convertSchemaRef(schema.AdditionalProperties.Schema)
+}
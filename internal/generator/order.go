@@ -0,0 +1,245 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// pathOrderDeclaration orders paths by the declaration of the first method of each.
+	pathOrderDeclaration = "declaration"
+	// pathOrderSorted orders paths by name.
+	pathOrderSorted = "sorted"
+)
+
+var (
+	// documentKeys is the order of the fields of OpenAPI and Swagger 2.0 documents.
+	documentKeys = []string{
+		"openapi", "swagger", "info", "jsonSchemaDialect", "host", "basePath", "schemes", "consumes",
+		"produces", "servers", "tags", "x-tagGroups", "paths", "webhooks", "components", "definitions",
+		"parameters", "responses", "securityDefinitions", "security", "externalDocs",
+	}
+	// infoKeys is the order of the fields of the info object.
+	infoKeys = []string{"title", "summary", "description", "termsOfService", "contact", "license", "version"}
+	// operationMethods is the order of the operations of path items.
+	operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+	// pathItemKeys is the order of the fields of path items.
+	pathItemKeys = append([]string{"$ref", "summary", "description", "servers", "parameters"}, operationMethods...)
+	// operationKeys is the order of the fields of operations.
+	operationKeys = []string{
+		"tags", "summary", "description", "externalDocs", "operationId", "consumes", "produces",
+		"parameters", "requestBody", "responses", "callbacks", "deprecated", "security", "servers",
+	}
+	// componentsKeys is the order of the fields of the components object.
+	componentsKeys = []string{
+		"schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes",
+		"links", "callbacks",
+	}
+)
+
+// validatePathOrder returns an error if the configured path order isn't supported.
+func (g *Generator) validatePathOrder() error {
+	switch *g.config.PathOrder {
+	case pathOrderDeclaration, pathOrderSorted:
+		return nil
+	default:
+		return fmt.Errorf("invalid path_order '%s'", *g.config.PathOrder)
+	}
+}
+
+// orderedProperties are properties written in the order of names.
+type orderedProperties struct {
+	names   []string
+	schemas openapi3.Schemas
+}
+
+// MarshalJSON writes the properties in order.
+func (p orderedProperties) MarshalJSON() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte('{')
+
+	for i, name := range p.names {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(p.schemas[name])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// orderProperties has the properties of the schemas of the document written in the order of the
+// fields they were built from, followed by any others by name.
+func (g *Generator) orderProperties(doc *openapi3.T, patches schemaPatches) {
+	seen := make(map[*openapi3.Schema]bool)
+
+	walkDocumentSchemas(doc, g.webhooks, func(_ string, schemaRef *openapi3.SchemaRef) {
+		walkSchemaRef(schemaRef, func(schemaRef *openapi3.SchemaRef) {
+			schema := schemaRef.Value
+			if schemaRef.Ref != "" || schema == nil || seen[schema] || len(schema.Properties) == 0 {
+				return
+			}
+
+			seen[schema] = true
+		})
+	})
+
+	// The schemas are only patched once all are found, since patching clears their properties.
+	for schema := range seen {
+		patches.setProperties(schema, orderedProperties{names: g.getPropertyOrder(schema), schemas: schema.Properties})
+	}
+}
+
+// getPropertyOrder returns the property names of the schema in the order of their fields followed
+// by any others by name.
+func (g *Generator) getPropertyOrder(schema *openapi3.Schema) []string {
+	names := make([]string, 0, len(schema.Properties))
+	added := make(map[string]bool, len(schema.Properties))
+
+	for _, name := range g.propertyOrders[schema] {
+		if _, ok := schema.Properties[name]; ok && !added[name] {
+			names = append(names, name)
+			added[name] = true
+		}
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		if !added[name] {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// orderDocument orders the fields of the document and of its info, paths, operations and components
+// as the specifications list them, followed by anything else in the order it's in. Paths are
+// ordered by the configured path order.
+func (g *Generator) orderDocument(root *yaml.Node) {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	orderKeys(root, documentKeys)
+	orderKeys(getMappingValue(root, "info"), infoKeys)
+	orderKeys(getMappingValue(root, "components"), componentsKeys)
+
+	for _, key := range []string{"paths", "webhooks"} {
+		paths := getMappingValue(root, key)
+		if paths == nil {
+			continue
+		}
+
+		if *g.config.PathOrder == pathOrderDeclaration {
+			orderKeys(paths, g.paths)
+		} else {
+			sortKeys(paths)
+		}
+
+		for i := 1; i < len(paths.Content); i += 2 {
+			pathItem := paths.Content[i]
+			orderKeys(pathItem, pathItemKeys)
+
+			for j := 0; j+1 < len(pathItem.Content); j += 2 {
+				if isOperationKey(pathItem.Content[j].Value) {
+					orderKeys(pathItem.Content[j+1], operationKeys)
+				}
+			}
+		}
+	}
+}
+
+// isOperationKey returns whether the field of a path item is an operation.
+func isOperationKey(key string) bool {
+	for _, method := range operationMethods {
+		if key == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getMappingValue returns the value of the key of the mapping or nil if it doesn't have the key.
+func getMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// orderKeys orders the keys of the mapping as they're in the order. Keys that aren't in it follow
+// in the order they're in.
+func orderKeys(node *yaml.Node, order []string) {
+	ranks := make(map[string]int, len(order))
+	for i, key := range order {
+		if _, ok := ranks[key]; !ok {
+			ranks[key] = i
+		}
+	}
+
+	sortMapping(node, func(a, b string) bool {
+		rankA, okA := ranks[a]
+		rankB, okB := ranks[b]
+
+		if okA && okB {
+			return rankA < rankB
+		}
+
+		return okA && !okB
+	})
+}
+
+// sortKeys sorts the keys of the mapping by name.
+func sortKeys(node *yaml.Node) {
+	sortMapping(node, func(a, b string) bool {
+		return a < b
+	})
+}
+
+// sortMapping sorts the pairs of the mapping by their keys, keeping the order of equal ones.
+func sortMapping(node *yaml.Node, less func(a, b string) bool) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return less(pairs[i][0].Value, pairs[j][0].Value)
+	})
+
+	for i, pair := range pairs {
+		node.Content[2*i], node.Content[2*i+1] = pair[0], pair[1]
+	}
+}
@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const (
+	// outputModeSingle generates one document for all files.
+	outputModeSingle = "single"
+	// outputModePerPackage generates a document for each package with services.
+	outputModePerPackage = "per_package"
+	// outputModePerService generates a document for each service.
+	outputModePerService = "per_service"
+	// outputModePerFile generates a document for each file with services.
+	outputModePerFile = "per_file"
+
+	// schemaRefPrefix is the prefix of references to component schemas.
+	schemaRefPrefix = "#/components/schemas/"
+)
+
+// output is a document to generate along with the services that have their paths in it.
+type output struct {
+	// name is the filename without the extension.
+	name string
+	// services holds the services of the document. All services are included if nil.
+	services map[*protogen.Service]bool
+	// components is whether the document only holds the component schemas shared by the others.
+	components bool
+}
+
+// hasService returns whether the paths of the service are in the document.
+func (o *output) hasService(service *protogen.Service) bool {
+	if o.components {
+		return false
+	}
+
+	return o.services == nil || o.services[service]
+}
+
+// hasFile returns whether the document holds anything of the file. This is every file for a single
+// document, otherwise the files with services in the document.
+func (o *output) hasFile(file *protogen.File) bool {
+	if o.services == nil {
+		return !o.components
+	}
+
+	for _, service := range file.Services {
+		if o.services[service] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterServices returns the services that have their paths in the document.
+func (o *output) filterServices(services []*protogen.Service) []*protogen.Service {
+	filtered := make([]*protogen.Service, 0, len(services))
+
+	for _, service := range services {
+		if o.hasService(service) {
+			filtered = append(filtered, service)
+		}
+	}
+
+	return filtered
+}
+
+// validateOutputMode returns an error if the configured output mode isn't supported.
+func (g *Generator) validateOutputMode() error {
+	switch *g.config.OutputMode {
+	case outputModeSingle, outputModePerPackage, outputModePerService, outputModePerFile:
+		return nil
+	default:
+		return fmt.Errorf("invalid output_mode '%s'", *g.config.OutputMode)
+	}
+}
+
+// buildOutputs returns the documents to generate for the configured output mode in the order of
+// the files and their services.
+func (g *Generator) buildOutputs(files []*protogen.File) []*output {
+	filename := *g.config.Filename
+
+	if *g.config.OutputMode == outputModeSingle {
+		return []*output{{name: filename}}
+	}
+
+	outputs := make([]*output, 0)
+	outputsByName := make(map[string]*output)
+
+	for _, file := range files {
+		for _, service := range file.Services {
+			var name string
+
+			switch *g.config.OutputMode {
+			case outputModePerPackage:
+				name = string(file.Desc.Package())
+			case outputModePerService:
+				name = string(service.Desc.FullName())
+			case outputModePerFile:
+				name = strings.TrimSuffix(file.Desc.Path(), ".proto")
+			}
+
+			if name == "" {
+				name = filename
+			} else {
+				name += "." + filename
+			}
+
+			o, ok := outputsByName[name]
+			if !ok {
+				o = &output{
+					name:     name,
+					services: make(map[*protogen.Service]bool),
+				}
+				outputsByName[name] = o
+				outputs = append(outputs, o)
+			}
+
+			o.services[service] = true
+		}
+	}
+
+	if *g.config.SharedComponents {
+		outputs = append(outputs, &output{
+			name:       filename + ".components",
+			components: true,
+		})
+	}
+
+	return outputs
+}
+
+// getComponentsRef returns the reference of the shared components document relative to the
+// document.
+func (g *Generator) getComponentsRef(o *output) string {
+	componentsFilename := *g.config.Filename + ".components" + g.getExtension()
+
+	rel, err := filepath.Rel(path.Dir(o.name), ".")
+	if err != nil {
+		return componentsFilename
+	}
+
+	return path.Join(filepath.ToSlash(rel), componentsFilename)
+}
+
+// useSharedComponents points the references to component schemas at the shared components document
+// and removes the schemas of the document.
+func (g *Generator) useSharedComponents(doc *openapi3.T, o *output) {
+	prefix := g.getComponentsRef(o) + schemaRefPrefix
+	if *g.config.OutputFormat == outputFormatSwagger2 {
+		// External references aren't converted, so they're pointed at the definitions already.
+		prefix = g.getComponentsRef(o) + "#/definitions/"
+	}
+
+	walkPathSchemas(doc, g.webhooks, func(_ string, schemaRef *openapi3.SchemaRef) {
+		walkSchemaRef(schemaRef, func(schemaRef *openapi3.SchemaRef) {
+			if strings.HasPrefix(schemaRef.Ref, schemaRefPrefix) {
+				schemaRef.Ref = prefix + strings.TrimPrefix(schemaRef.Ref, schemaRefPrefix)
+			}
+		})
+	})
+
+	doc.Components.Schemas = make(openapi3.Schemas)
+}
+
+// pruneSchemas removes the component schemas that can't be reached from the paths, webhooks and
+// other components of the document.
+func (g *Generator) pruneSchemas(doc *openapi3.T) {
+	reachable := make(map[string]bool)
+
+	var visit func(schemaRef *openapi3.SchemaRef)
+	visit = func(schemaRef *openapi3.SchemaRef) {
+		walkSchemaRef(schemaRef, func(schemaRef *openapi3.SchemaRef) {
+			name := strings.TrimPrefix(schemaRef.Ref, schemaRefPrefix)
+			if name == schemaRef.Ref || reachable[name] {
+				return
+			}
+
+			reachable[name] = true
+			visit(doc.Components.Schemas[name])
+		})
+	}
+
+	walkPathSchemas(doc, g.webhooks, func(_ string, schemaRef *openapi3.SchemaRef) {
+		visit(schemaRef)
+	})
+
+	for name := range doc.Components.Schemas {
+		if !reachable[name] {
+			delete(doc.Components.Schemas, name)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/speakeasy-api/jsonpath/pkg/jsonpath"
+	"gopkg.in/yaml.v3"
+)
+
+// overlay is an OpenAPI Overlay document. See https://spec.openapis.org/overlay/v1.0.0.html
+type overlay struct {
+	Overlay string `yaml:"overlay"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Extends string           `yaml:"extends,omitempty"`
+	Actions []*overlayAction `yaml:"actions"`
+}
+
+// overlayAction updates or removes the nodes selected by its target.
+type overlayAction struct {
+	// Target is a JSONPath expression selecting the nodes of the action.
+	Target      string `yaml:"target"`
+	Description string `yaml:"description,omitempty"`
+	// Update is merged into objects and appended to arrays.
+	Update yaml.Node `yaml:"update,omitempty"`
+	// Remove removes the nodes from their parents.
+	Remove bool `yaml:"remove,omitempty"`
+}
+
+// loadOverlay loads and validates the overlay file.
+func loadOverlay(filename string) (*overlay, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay '%s': %w", filename, err)
+	}
+
+	o := new(overlay)
+	err = yaml.Unmarshal(data, o)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse overlay '%s': %w", filename, err)
+	}
+
+	if !strings.HasPrefix(o.Overlay, "1.") {
+		return nil, fmt.Errorf("overlay '%s' has unsupported version '%s'", filename, o.Overlay)
+	}
+
+	if len(o.Actions) == 0 {
+		return nil, fmt.Errorf("overlay '%s' has no actions", filename)
+	}
+
+	for i, action := range o.Actions {
+		if action.Target == "" {
+			return nil, fmt.Errorf("action %d of overlay '%s' is missing a target", i, filename)
+		}
+	}
+
+	return o, nil
+}
+
+// applyOverlays applies the configured overlays in order to the document.
+func (g *Generator) applyOverlays(root *yaml.Node) error {
+	for _, filename := range *g.config.Overlays {
+		o, err := loadOverlay(filename)
+		if err != nil {
+			return err
+		}
+
+		err = applyOverlayActions(root, o.Actions)
+		if err != nil {
+			return fmt.Errorf("overlay '%s': %w", filename, err)
+		}
+	}
+
+	return nil
+}
+
+// applyOverlayActions applies the actions in order to the document.
+func applyOverlayActions(root *yaml.Node, actions []*overlayAction) error {
+	for _, action := range actions {
+		path, err := jsonpath.NewPath(action.Target)
+		if err != nil {
+			return fmt.Errorf("invalid target '%s': %w", action.Target, err)
+		}
+
+		nodes := path.Query(root)
+
+		if action.Remove {
+			parents := make(map[*yaml.Node]*yaml.Node)
+			indexParents(root, parents)
+
+			for _, node := range nodes {
+				removeNode(parents[node], node)
+			}
+
+			continue
+		}
+
+		if action.Update.IsZero() {
+			continue
+		}
+
+		for _, node := range nodes {
+			err = mergeNode(node, &action.Update)
+			if err != nil {
+				return fmt.Errorf("failed to update target '%s': %w", action.Target, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// indexParents adds the parent of each node under the node to the map.
+func indexParents(node *yaml.Node, parents map[*yaml.Node]*yaml.Node) {
+	for _, child := range node.Content {
+		parents[child] = node
+		indexParents(child, parents)
+	}
+}
+
+// removeNode removes the node from its parent. For mappings, the key is removed along with it.
+func removeNode(parent, node *yaml.Node) {
+	if parent == nil {
+		return
+	}
+
+	switch parent.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(parent.Content); i += 2 {
+			if parent.Content[i] == node {
+				parent.Content = append(parent.Content[:i-1], parent.Content[i+1:]...)
+				return
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range parent.Content {
+			if child == node {
+				parent.Content = append(parent.Content[:i], parent.Content[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// mergeNode merges the update into the target. Objects are merged recursively, arrays have the
+// update appended and anything else is replaced.
+func mergeNode(target, update *yaml.Node) error {
+	if len(update.Content) > 0 {
+		// Empty objects and arrays are written as {} and [], which shouldn't stick with content.
+		target.Style &^= yaml.FlowStyle
+	}
+
+	switch {
+	case target.Kind == yaml.MappingNode && update.Kind == yaml.MappingNode:
+	outer:
+		for i := 0; i < len(update.Content); i += 2 {
+			key, value := update.Content[i], update.Content[i+1]
+
+			for j := 0; j < len(target.Content); j += 2 {
+				if target.Content[j].Value != key.Value {
+					continue
+				}
+
+				existing := target.Content[j+1]
+				if (existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode) || existing.Kind == yaml.SequenceNode {
+					if err := mergeNode(existing, value); err != nil {
+						return err
+					}
+				} else {
+					target.Content[j+1] = cloneNode(value)
+				}
+
+				continue outer
+			}
+
+			target.Content = append(target.Content, cloneNode(key), cloneNode(value))
+		}
+	case target.Kind == yaml.SequenceNode:
+		if update.Kind == yaml.SequenceNode {
+			for _, child := range update.Content {
+				target.Content = append(target.Content, cloneNode(child))
+			}
+		} else {
+			target.Content = append(target.Content, cloneNode(update))
+		}
+	default:
+		return fmt.Errorf("can't merge a %s into a %s", nodeKindName(update.Kind), nodeKindName(target.Kind))
+	}
+
+	return nil
+}
+
+// cloneNode returns a deep copy of the node so the targets of an update don't share nodes.
+func cloneNode(node *yaml.Node) *yaml.Node {
+	clone := *node
+	clone.Content = make([]*yaml.Node, len(node.Content))
+
+	for i, child := range node.Content {
+		clone.Content[i] = cloneNode(child)
+	}
+
+	return &clone
+}
+
+// nodeKindName returns the JSON name of the kind of node.
+func nodeKindName(kind yaml.Kind) string {
+	switch kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	default:
+		return "value"
+	}
+}
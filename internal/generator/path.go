@@ -11,6 +11,7 @@ import (
 	oapiv1 "github.com/technicallyjosh/protoc-gen-openapi/api/oapi/v1"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 func NewServer(host string) (*openapi3.Server, error) {
@@ -31,14 +32,15 @@ func NewServer(host string) (*openapi3.Server, error) {
 }
 
 // addPathsToDoc adds paths from services and methods to the OAPI doc. This includes all request and
-// response bodies.
-func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service) error {
+// response bodies. Errors are reported so the remaining services and methods are still checked.
+func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service) {
 	// Default to config-defined.
 	contentType := *g.config.ContentType
 	host := *g.config.Host
 
 	for _, service := range services {
 		var pathPrefix string
+		var fileSecurity []*oapiv1.Security
 
 		// Apply/Override file options.
 		extFile := proto.GetExtension(service.Desc.ParentFile().Options(), oapiv1.E_File)
@@ -46,6 +48,7 @@ func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service)
 			fileOptions := extFile.(*oapiv1.FileOptions)
 			host = fileOptions.Host
 			pathPrefix = fileOptions.Prefix
+			fileSecurity = fileOptions.Security
 		}
 
 		tagName := string(service.Desc.FullName())
@@ -58,8 +61,6 @@ func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service)
 			serviceOptions = extService.(*oapiv1.ServiceOptions)
 		}
 
-		security := make([]*oapiv1.Security, 0)
-
 		var servers openapi3.Servers
 
 		if serviceOptions.Host != "" {
@@ -68,7 +69,8 @@ func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service)
 
 			server, err := NewServer(serviceOptions.Host)
 			if err != nil {
-				return err
+				g.report(errorAt(locateOption(service.Desc, "host"), "%s", err.Error()))
+				continue
 			}
 
 			doc.Servers = append(doc.Servers, server)
@@ -76,10 +78,11 @@ func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service)
 		}
 
 		if len(serviceOptions.Servers) > 0 {
-			for _, serviceServer := range serviceOptions.Servers {
+			for i, serviceServer := range serviceOptions.Servers {
 				server, err := NewServer(serviceServer.Url)
 				if err != nil {
-					return err
+					g.report(errorAt(locateOption(service.Desc, "servers", i), "%s", err.Error()))
+					continue
 				}
 
 				servers = append(servers, server)
@@ -94,10 +97,11 @@ func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service)
 				servers = append(servers, doc.Servers...)
 			}
 
-			for _, addServer := range serviceOptions.AddServers {
+			for i, addServer := range serviceOptions.AddServers {
 				server, err := NewServer(addServer.Url)
 				if err != nil {
-					return err
+					g.report(errorAt(locateOption(service.Desc, "add_servers", i), "%s", err.Error()))
+					continue
 				}
 
 				servers = append(servers, server)
@@ -114,19 +118,12 @@ func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service)
 			contentType = serviceOptions.ContentType
 		}
 
-		if len(serviceOptions.Security) > 0 {
-			// Use service defined security.
-			for _, s := range serviceOptions.Security {
-				// If one is empty, this is how we'll clear it on an override.
-				// e.g. security: {}
-				if s.Name == "" {
-					security = make([]*oapiv1.Security, 0)
-					break
-				}
+		g.resolveSecurity(doc, service.Desc, serviceOptions.Security)
 
-				security = append(security, s)
-			}
-		}
+		// Security without a mode replaces the one of the file and an empty one, e.g. security: {},
+		// clears it to inherit the file's like before modes existed.
+		securityMode := g.getSecurityMode(service.Desc, serviceOptions.SecurityMode, serviceOptions.Security, oapiv1.SecurityMode_SECURITY_MODE_REPLACE, oapiv1.SecurityMode_SECURITY_MODE_INHERIT)
+		security := applySecurityMode(securityMode, serviceOptions.Security, nil, fileSecurity)
 
 		serviceDescription := g.parseComments(service.Comments.Leading).Description
 
@@ -142,11 +139,12 @@ func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service)
 		if tagGroup != "" {
 			err := addTagGroup(doc, tagGroup, tagName)
 			if err != nil {
-				return err
+				g.report(errorAt(locateOption(service.Desc, "x_tag_group"), "%s", err.Error()))
 			}
 		}
 
 		parameters, err := g.createParameters(
+			service.Desc,
 			pathPrefix,
 			serviceOptions.PathParameter,
 			serviceOptions.QueryParameter,
@@ -154,7 +152,8 @@ func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service)
 			serviceOptions.CookieParameter,
 		)
 		if err != nil {
-			return err
+			g.report(locatedAt(service.Desc, err))
+			continue
 		}
 
 		for _, method := range service.Methods {
@@ -169,37 +168,38 @@ func (g *Generator) addPathsToDoc(doc *openapi3.T, services []*protogen.Service)
 				pathPrefix:        pathPrefix,
 				packageName:       packageName,
 				serviceParameters: parameters,
+				fileSecurity:      fileSecurity,
 				security:          security,
 				servers:           servers,
 			})
 			if err != nil {
-				return err
+				g.report(locatedAt(method.Desc, err))
 			}
 		}
 	}
-
-	return nil
 }
 
-func (g *Generator) createParameters(path string, pathParams, queryParams, headerParams, cookieParams []*oapiv1.Parameter) (openapi3.Parameters, error) {
-	parameters, err := g.parseParameters(openapi3.ParameterInPath, path, pathParams)
+// createParameters parses and returns the parameters defined in the options of the service or
+// method.
+func (g *Generator) createParameters(desc protoreflect.Descriptor, path string, pathParams, queryParams, headerParams, cookieParams []*oapiv1.Parameter) (openapi3.Parameters, error) {
+	parameters, err := g.parseParameters(desc, openapi3.ParameterInPath, path, pathParams)
 	if err != nil {
 		return nil, err
 	}
 
-	queryParameters, err := g.parseParameters(openapi3.ParameterInQuery, "", queryParams)
+	queryParameters, err := g.parseParameters(desc, openapi3.ParameterInQuery, "", queryParams)
 	if err != nil {
 		return nil, err
 	}
 	parameters = append(parameters, queryParameters...)
 
-	headerParameters, err := g.parseParameters(openapi3.ParameterInHeader, "", headerParams)
+	headerParameters, err := g.parseParameters(desc, openapi3.ParameterInHeader, "", headerParams)
 	if err != nil {
 		return nil, err
 	}
 	parameters = append(parameters, headerParameters...)
 
-	cookieParameters, err := g.parseParameters(openapi3.ParameterInCookie, "", cookieParams)
+	cookieParameters, err := g.parseParameters(desc, openapi3.ParameterInCookie, "", cookieParams)
 	if err != nil {
 		return nil, err
 	}
@@ -208,13 +208,15 @@ func (g *Generator) createParameters(path string, pathParams, queryParams, heade
 	return parameters, nil
 }
 
-// parseParameters parses and returns openapi3 converted parameters from defined parameters.
-func (g *Generator) parseParameters(in, path string, parameters []*oapiv1.Parameter) (openapi3.Parameters, error) {
+// parseParameters parses and returns openapi3 converted parameters from defined parameters. Errors
+// are located at the parameter in the options of the service or method.
+func (g *Generator) parseParameters(desc protoreflect.Descriptor, in, path string, parameters []*oapiv1.Parameter) (openapi3.Parameters, error) {
 	params := make(openapi3.Parameters, 0)
+	optionName := in + "_parameter"
 
-	for _, parameter := range parameters {
+	for i, parameter := range parameters {
 		if in == openapi3.ParameterInPath && !strings.Contains(path, fmt.Sprintf("{%s}", parameter.Name)) {
-			return nil, fmt.Errorf("parameter {%s} is missing from path %s", parameter.Name, path)
+			return nil, errorAt(locateOption(desc, optionName, i), "parameter {%s} is missing from path %s", parameter.Name, path)
 		}
 
 		paramRef := &openapi3.ParameterRef{
@@ -237,7 +239,7 @@ func (g *Generator) parseParameters(in, path string, parameters []*oapiv1.Parame
 		case oapiv1.Parameter_TYPE_BOOLEAN:
 			paramType = openapi3.TypeBoolean
 		default:
-			return nil, fmt.Errorf("invalid parameter type: %s", parameter.Type)
+			return nil, errorAt(locateOption(desc, optionName, i, "type"), "invalid parameter type: %s", parameter.Type)
 		}
 
 		paramRef.Value.Schema = &openapi3.SchemaRef{
@@ -263,7 +265,7 @@ func (g *Generator) parseParameters(in, path string, parameters []*oapiv1.Parame
 				paramRef.Value.Required = true
 			})
 			if err != nil {
-				return nil, err
+				return nil, errorAt(locateOption(desc, optionName, i, "options"), "%s", err.Error())
 			}
 		}
 
@@ -285,24 +287,44 @@ type addOperationParams struct {
 	pathPrefix        string
 	packageName       string
 	serviceParameters openapi3.Parameters
-	security          []*oapiv1.Security
+	fileSecurity      []*oapiv1.Security
+	// security is the security of the operations. Nil inherits the security of the document and
+	// empty requires none.
+	security []*oapiv1.Security
 }
 
-// addOperation creates an operation for a path and adds it.
-// TODO: Break into smaller bits.
+// addOperation creates an operation for each HTTP binding of a method and adds it. The bindings
+// come from the method options, the google.api.http rule or the configured routing in that order.
 func (g *Generator) addOperation(p addOperationParams) error {
 	servers := p.servers
 	contentType := p.contentType
 
-	operationID := string(p.service.Desc.Name() + "_" + p.method.Desc.Name())
-	description := g.parseComments(p.method.Comments.Leading).Description
-
 	var methodOptions *oapiv1.MethodOptions
+	var bindings []*httpBinding
 
-	// Method options. If not present, continue to next.
+	// Method options. If not present, fall back to google.api.http, then the routing and then
+	// continue to next.
 	extMethod := proto.GetExtension(p.method.Desc.Options(), oapiv1.E_Method)
 	if extMethod != nil && extMethod != oapiv1.E_Method.InterfaceOf(oapiv1.E_Method.Zero()) {
 		methodOptions = extMethod.(*oapiv1.MethodOptions)
+
+		binding, err := g.newMethodBinding(p.method, methodOptions)
+		if err != nil {
+			return err
+		}
+
+		bindings = append(bindings, binding)
+	} else if rule := getHTTPRule(p.method); rule != nil {
+		methodOptions = new(oapiv1.MethodOptions)
+
+		var err error
+		bindings, err = newRuleBindings(p.method, rule)
+		if err != nil {
+			return err
+		}
+	} else if binding := g.newRoutingBinding(p.method); binding != nil {
+		methodOptions = new(oapiv1.MethodOptions)
+		bindings = append(bindings, binding)
 	} else {
 		return nil
 	}
@@ -310,7 +332,7 @@ func (g *Generator) addOperation(p addOperationParams) error {
 	if methodOptions.Host != "" {
 		server, err := NewServer(methodOptions.Host)
 		if err != nil {
-			return err
+			return errorAt(locateOption(p.method.Desc, "host"), "%s", err.Error())
 		}
 		p.doc.Servers = append(p.doc.Servers, server)
 		servers = append(servers, server)
@@ -319,10 +341,10 @@ func (g *Generator) addOperation(p addOperationParams) error {
 	if len(methodOptions.Servers) > 0 {
 		// If defined at the method level, delete what came from service.
 		servers = openapi3.Servers{}
-		for _, methodServer := range methodOptions.Servers {
+		for i, methodServer := range methodOptions.Servers {
 			server, err := NewServer(methodServer.Url)
 			if err != nil {
-				return err
+				return errorAt(locateOption(p.method.Desc, "servers", i), "%s", err.Error())
 			}
 
 			servers = append(servers, server)
@@ -330,10 +352,10 @@ func (g *Generator) addOperation(p addOperationParams) error {
 	}
 
 	if len(methodOptions.AddServers) > 0 {
-		for _, addServer := range methodOptions.AddServers {
+		for i, addServer := range methodOptions.AddServers {
 			server, err := NewServer(addServer.Url)
 			if err != nil {
-				return err
+				return errorAt(locateOption(p.method.Desc, "add_servers", i), "%s", err.Error())
 			}
 
 			servers = append(servers, server)
@@ -345,25 +367,54 @@ func (g *Generator) addOperation(p addOperationParams) error {
 		contentType = methodOptions.ContentType
 	}
 
-	if len(methodOptions.Security) > 0 {
-		// Use method defined security.
-		for _, s := range methodOptions.Security {
-			// If one is empty, this is how we'll clear it on an override.
-			// e.g. security: {}
-			if s.Name == "" {
-				p.security = []*oapiv1.Security{
-					{
-						Name: "___remove",
-					},
-				}
-				break
-			}
+	g.resolveSecurity(p.doc, p.method.Desc, methodOptions.Security)
+
+	// Security without a mode is added to the one of the service only and an empty one, e.g.
+	// security: {}, removes it like before modes existed. Appending otherwise adds to whatever is
+	// inherited.
+	securityMode := g.getSecurityMode(p.method.Desc, methodOptions.SecurityMode, methodOptions.Security, oapiv1.SecurityMode_SECURITY_MODE_APPEND, oapiv1.SecurityMode_SECURITY_MODE_NONE)
+
+	appendTo := p.security
+	if appendTo == nil && methodOptions.SecurityMode == oapiv1.SecurityMode_SECURITY_MODE_APPEND {
+		appendTo = p.fileSecurity
+	}
+
+	p.security = applySecurityMode(securityMode, methodOptions.Security, p.security, appendTo)
 
-			p.security = append(p.security, s)
+	if methodOptions.Status == 0 {
+		// Default to 200 OK.
+		methodOptions.Status = http.StatusOK
+	}
+
+	p.servers = servers
+	p.contentType = contentType
+
+	for i, binding := range bindings {
+		operationID := string(p.service.Desc.Name() + "_" + p.method.Desc.Name())
+
+		// Additional bindings need their own operation IDs.
+		if i > 0 {
+			operationID = fmt.Sprintf("%s_%d", operationID, i)
+		}
+
+		err := g.addBindingOperation(p, methodOptions, binding, operationID)
+		if err != nil {
+			return err
 		}
 	}
 
-	var methodPath, methodName string
+	return nil
+}
+
+// addBindingOperation creates an operation for a single HTTP binding of a method and adds it.
+// TODO: Break into smaller bits.
+func (g *Generator) addBindingOperation(p addOperationParams, methodOptions *oapiv1.MethodOptions, binding *httpBinding, operationID string) error {
+	servers := p.servers
+	contentType := p.contentType
+	description := g.parseComments(p.method.Comments.Leading).Description
+
+	methodName := binding.method
+	methodPath := binding.path
 
 	op := &openapi3.Operation{
 		Tags:        []string{p.tagName},
@@ -376,8 +427,8 @@ func (g *Generator) addOperation(p addOperationParams) error {
 		Parameters:  make(openapi3.Parameters, 0),
 	}
 
-	if len(p.security) > 0 {
-		op.Security = new(openapi3.SecurityRequirements)
+	if p.security != nil {
+		op.Security = openapi3.NewSecurityRequirements()
 		for _, sec := range p.security {
 			req := openapi3.SecurityRequirement{
 				sec.Name: make([]string, 0),
@@ -391,31 +442,21 @@ func (g *Generator) addOperation(p addOperationParams) error {
 		}
 	}
 
-	switch m := methodOptions.Method.(type) {
-	case *oapiv1.MethodOptions_Get:
-		methodName = http.MethodGet
-		methodPath = m.Get
-	case *oapiv1.MethodOptions_Put:
-		methodName = http.MethodPut
-		methodPath = m.Put
-	case *oapiv1.MethodOptions_Post:
-		methodName = http.MethodPost
-		methodPath = m.Post
-	case *oapiv1.MethodOptions_Delete:
-		methodName = http.MethodDelete
-		methodPath = m.Delete
-	case *oapiv1.MethodOptions_Patch:
-		methodName = http.MethodPatch
-		methodPath = m.Patch
-	default:
-		return fmt.Errorf("method '%s' is missing a method", p.method.Desc.FullName())
+	g.operationSources[op] = p.method
+
+	if methodOptions.Webhook && *g.config.OpenAPIVersion != openAPIVersion31 {
+		return errorAt(locateOption(p.method.Desc, "webhook"), "webhook method '%s' requires openapi_version %s", p.method.Desc.FullName(), openAPIVersion31)
 	}
 
-	// If the method's path starts with a "/", don't append the prefix from the service.
-	if !strings.HasPrefix(methodPath, "/") {
+	// If the method's path starts with a "/", don't append the prefix from the service. Webhooks
+	// are named by their path, so they never have the prefix.
+	if !strings.HasPrefix(methodPath, "/") && !methodOptions.Webhook {
 		methodPath = path.Join(p.pathPrefix, methodPath)
 	}
 
+	// Patterns are removed from the path variables so the path is a valid OAPI path template.
+	methodPath, pathVariables := parsePathTemplate(methodPath)
+
 	var defaultResponseDesc string
 
 	// Set the default response from the method or service if defined. Otherwise, use the
@@ -425,7 +466,7 @@ func (g *Generator) addOperation(p addOperationParams) error {
 		schemaName := g.getPackageSchema(p.packageName, methodOptions.DefaultResponse)
 		_, ok := p.doc.Components.Schemas[schemaName]
 		if !ok {
-			return fmt.Errorf("schema '%s' for method '%s' default response not found", schemaName, p.method.Desc.FullName())
+			return errorAt(locateOption(p.method.Desc, "default_response"), "schema '%s' for method '%s' default response not found", schemaName, p.method.Desc.FullName())
 		}
 
 		op.Responses["default"] = &openapi3.ResponseRef{
@@ -445,7 +486,7 @@ func (g *Generator) addOperation(p addOperationParams) error {
 		schemaName := g.getPackageSchema(p.packageName, p.serviceOptions.DefaultResponse)
 		_, ok := p.doc.Components.Schemas[schemaName]
 		if !ok {
-			return fmt.Errorf("schema '%s' for service '%s' default response not found", schemaName, p.service.Desc.FullName())
+			return errorAt(locateOption(p.service.Desc, "default_response"), "schema '%s' for service '%s' default response not found", schemaName, p.service.Desc.FullName())
 		}
 
 		op.Responses["default"] = &openapi3.ResponseRef{
@@ -469,11 +510,6 @@ func (g *Generator) addOperation(p addOperationParams) error {
 		}
 	}
 
-	if methodOptions.Status == 0 {
-		// Default to 200 OK.
-		methodOptions.Status = http.StatusOK
-	}
-
 	requestContent := openapi3.Content{
 		contentType: &openapi3.MediaType{
 			Schema: &openapi3.SchemaRef{
@@ -495,6 +531,7 @@ func (g *Generator) addOperation(p addOperationParams) error {
 	}
 
 	methodParameters, err := g.createParameters(
+		p.method.Desc,
 		methodPath,
 		methodOptions.PathParameter,
 		methodOptions.QueryParameter,
@@ -520,17 +557,61 @@ func (g *Generator) addOperation(p addOperationParams) error {
 		}
 	}
 
-	op.Parameters = append(p.serviceParameters, methodParameters...)
+	// The service parameters are shared by its methods, so they're copied before appending.
+	op.Parameters = append(append(openapi3.Parameters{}, p.serviceParameters...), methodParameters...)
+
+	// Path variables that aren't defined as parameters are bound to the input fields.
+	bound := make(map[string]bool)
+
+	if !isWellKnownType(p.method.Input) {
+		pathParameters, boundFields, err := g.buildPathParameters(p.doc, p.method.Input, pathVariables, op.Parameters)
+		if err != nil {
+			return err
+		}
 
-	if methodName != http.MethodGet {
+		op.Parameters = append(op.Parameters, pathParameters...)
+		bound = boundFields
+	}
+
+	// Query parameters are expanded from the input fields that aren't bound to the path or body.
+	if binding.query && !isWellKnownType(p.method.Input) {
+		queryBound := bound
+
+		if binding.body != "" {
+			queryBound = make(map[string]bool)
+			for name := range bound {
+				queryBound[name] = true
+			}
+
+			if field := findField(p.method.Input, binding.body); field != nil {
+				queryBound[g.getPropertyPath(p.method.Input, binding.body)] = true
+			}
+		}
+
+		queryParameters, err := g.buildQueryParameters(p.doc, p.method.Input, "", queryBound)
+		if err != nil {
+			return err
+		}
+
+		for _, queryParam := range queryParameters {
+			// Explicitly defined parameters win over the ones from the input.
+			if !hasParameter(op.Parameters, queryParam.Value.In, queryParam.Value.Name) {
+				op.Parameters = append(op.Parameters, queryParam)
+			}
+		}
+	}
+
+	var requestSchemaRef *openapi3.SchemaRef
+
+	switch binding.body {
+	case "":
+	case "*":
 		inputFullName := string(p.method.Input.Desc.FullName())
-		message := allMessages.Get(inputFullName)
+		message := g.messages.Get(inputFullName)
 
-		// If another type is defined such as google.protobuf.Any or
-		// google.protobuf.Empty, for now we'll just exit.
-		// TODO: support `Any` type for requests 🤔
-		if message != nil {
-			requestSchemaRef := &openapi3.SchemaRef{
+		if message != nil && len(bound) > 0 {
+			// Bound fields are left out of the body, so it's always built inline.
+			requestSchemaRef = &openapi3.SchemaRef{
 				Value: &openapi3.Schema{
 					Properties: make(openapi3.Schemas),
 				},
@@ -541,26 +622,76 @@ func (g *Generator) addOperation(p addOperationParams) error {
 				return err
 			}
 
-			requestContent.Get(contentType).Schema = requestSchemaRef
+			for name := range bound {
+				requestSchemaRef = g.removeProperty(p.doc, requestSchemaRef, name)
+			}
+		} else if message != nil {
+			requestSchemaRef, err = g.newMessageSchemaRef(p.doc, message)
+			if err != nil {
+				return err
+			}
+		} else if p.method.Input.Desc.FullName() != emptyFullName {
+			// Well-known types other than google.protobuf.Empty are sent as their protojson
+			// representation.
+			if wellKnownSchema := newWellKnownSchema(p.method.Input.Desc.FullName()); wellKnownSchema != nil {
+				requestSchemaRef = wellKnownSchema.NewRef()
+			}
+		}
+	default:
+		// A single field of the input is the body.
+		field := findField(p.method.Input, binding.body)
+		if field == nil {
+			return fmt.Errorf("body field '%s' of method '%s' not found", binding.body, p.method.Desc.FullName())
+		}
+
+		requestSchemaRef, _, err = g.buildFieldSchemaRef(p.doc, field)
+		if err != nil {
+			return err
+		}
 
-			op.RequestBody = &openapi3.RequestBodyRef{
-				Value: &openapi3.RequestBody{
-					Content: requestContent,
-				},
+		bodyPrefix := g.getPropertyPath(p.method.Input, binding.body) + "."
+		for name := range bound {
+			if strings.HasPrefix(name, bodyPrefix) {
+				requestSchemaRef = g.removeProperty(p.doc, requestSchemaRef, strings.TrimPrefix(name, bodyPrefix))
 			}
 		}
 	}
 
-	outputFullName := string(p.method.Output.Desc.FullName())
-	message := allMessages.Get(outputFullName)
-	responseSchema := &openapi3.Schema{
-		Properties: make(openapi3.Schemas),
+	if requestSchemaRef != nil {
+		requestContent.Get(contentType).Schema = requestSchemaRef
+
+		op.RequestBody = &openapi3.RequestBodyRef{
+			Value: &openapi3.RequestBody{
+				Content: requestContent,
+			},
+		}
 	}
-	err = g.buildSchema(p.doc, message, responseSchema.NewRef())
-	if err != nil {
-		return err
+
+	var responseSchemaRef *openapi3.SchemaRef
+
+	if binding.responseBody != "" {
+		// A single field of the output is the body.
+		field := findField(p.method.Output, binding.responseBody)
+		if field == nil {
+			return fmt.Errorf("response body field '%s' of method '%s' not found", binding.responseBody, p.method.Desc.FullName())
+		}
+
+		responseSchemaRef, _, err = g.buildFieldSchemaRef(p.doc, field)
+		if err != nil {
+			return err
+		}
+	} else if wellKnownSchema := newWellKnownSchema(p.method.Output.Desc.FullName()); wellKnownSchema != nil {
+		responseSchemaRef = wellKnownSchema.NewRef()
+	} else {
+		outputFullName := string(p.method.Output.Desc.FullName())
+		message := g.messages.Get(outputFullName)
+
+		responseSchemaRef, err = g.newMessageSchemaRef(p.doc, message)
+		if err != nil {
+			return err
+		}
 	}
-	responseContent.Get(contentType).Schema = responseSchema.NewRef()
+	responseContent.Get(contentType).Schema = responseSchemaRef
 
 	responseCode := fmt.Sprintf("%d", methodOptions.Status)
 	var responseDescription string
@@ -571,13 +702,26 @@ func (g *Generator) addOperation(p addOperationParams) error {
 		},
 	}
 
+	if g.config.OperationHook != nil {
+		err = g.config.OperationHook(p.method, op)
+		if err != nil {
+			return locatedAt(p.method.Desc, err)
+		}
+	}
+
+	paths := p.doc.Paths
+	if methodOptions.Webhook {
+		paths = g.webhooks
+	}
+
 	// Check for an existing path an append if it exists.
-	existingPath := p.doc.Paths.Find(methodPath)
+	existingPath := paths.Find(methodPath)
 	if existingPath == nil {
 		pathItem := new(openapi3.PathItem)
 		pathItem.SetOperation(methodName, op)
 
-		p.doc.Paths[methodPath] = pathItem
+		paths[methodPath] = pathItem
+		g.paths = append(g.paths, methodPath)
 	} else {
 		if existingPath.GetOperation(methodName) != nil {
 			return fmt.Errorf("duplicate method '%s' for path '%s'", methodName, methodPath)
@@ -589,3 +733,24 @@ func (g *Generator) addOperation(p addOperationParams) error {
 
 	return nil
 }
+
+// newMessageSchemaRef returns the schema of the message as a body. It's a reference to the
+// component schema of the message or built out inline if the message isn't a body component.
+func (g *Generator) newMessageSchemaRef(doc *openapi3.T, message *protogen.Message) (*openapi3.SchemaRef, error) {
+	if g.isBodyComponent(message) {
+		ref, err := g.addMessageSchema(doc, message)
+		if err != nil {
+			return nil, err
+		}
+
+		return &openapi3.SchemaRef{Ref: ref}, nil
+	}
+
+	schemaRef := &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Properties: make(openapi3.Schemas),
+		},
+	}
+
+	return schemaRef, g.buildSchema(doc, message, schemaRef)
+}
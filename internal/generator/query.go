@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/technicallyjosh/protoc-gen-openapi/internal/generator/util"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// buildQueryParameters returns query parameters for the scalar, enum and repeated scalar fields of
+// the message. Fields of nested messages use dotted names. e.g. "filter.name". Maps, repeated
+// messages, messages that can't be represented as a single value and bound fields are skipped.
+func (g *Generator) buildQueryParameters(doc *openapi3.T, message *protogen.Message, prefix string, bound map[string]bool) (openapi3.Parameters, error) {
+	params := make(openapi3.Parameters, 0)
+
+	// Track the message while it's being expanded so recursive messages end.
+	messageName := util.FullName(message)
+	g.building[messageName]++
+	defer func() {
+		g.building[messageName]--
+	}()
+
+	for _, field := range message.Fields {
+		fieldName := g.getFieldName(field)
+
+		// Bound messages are skipped along with all of their fields.
+		if field.Desc.IsMap() || bound[prefix+fieldName] {
+			continue
+		}
+
+		if field.Message != nil && !isWellKnownType(field.Message) {
+			if field.Desc.IsList() || g.building[util.FullName(field.Message)] > 0 {
+				continue
+			}
+
+			nestedParams, err := g.buildQueryParameters(doc, field.Message, prefix+fieldName+".", bound)
+			if err != nil {
+				return nil, err
+			}
+
+			params = append(params, nestedParams...)
+			continue
+		}
+
+		param, err := g.newFieldParameter(doc, field, openapi3.ParameterInQuery, prefix+fieldName)
+		if err != nil {
+			return nil, err
+		}
+
+		if param != nil {
+			params = append(params, param)
+		}
+	}
+
+	return params, nil
+}
+
+// newFieldParameter returns a parameter for the field or nil if the field can't be represented as
+// a single value.
+func (g *Generator) newFieldParameter(doc *openapi3.T, field *protogen.Field, in, name string) (*openapi3.ParameterRef, error) {
+	schemaRef, required, err := g.buildFieldSchemaRef(doc, field)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isQuerySchema(doc, schemaRef) {
+		return nil, nil
+	}
+
+	param := &openapi3.Parameter{
+		Name:     name,
+		In:       in,
+		Required: in == openapi3.ParameterInPath || required,
+		Schema:   schemaRef,
+	}
+
+	if schemaRef.Value != nil {
+		param.Description = strings.TrimSpace(schemaRef.Value.Description)
+		param.Deprecated = schemaRef.Value.Deprecated
+		schemaRef.Value.Description = ""
+	}
+
+	return &openapi3.ParameterRef{Value: param}, nil
+}
+
+// isQuerySchema returns whether the schema can be sent as a query parameter. Only primitives,
+// referenced enums and arrays of those can.
+func isQuerySchema(doc *openapi3.T, schemaRef *openapi3.SchemaRef) bool {
+	schema := schemaRef.Value
+	if schemaRef.Ref != "" {
+		schema = getRefSchema(doc, schemaRef.Ref)
+	}
+
+	if schema == nil {
+		return false
+	}
+
+	switch schema.Type {
+	case openapi3.TypeString, openapi3.TypeInteger, openapi3.TypeNumber, openapi3.TypeBoolean:
+		return true
+	case openapi3.TypeArray:
+		return schema.Items != nil && isQuerySchema(doc, schema.Items) && !isArraySchema(doc, schema.Items)
+	default:
+		return false
+	}
+}
+
+// isArraySchema returns whether the schema or the schema it references is an array.
+func isArraySchema(doc *openapi3.T, schemaRef *openapi3.SchemaRef) bool {
+	schema := schemaRef.Value
+	if schemaRef.Ref != "" {
+		schema = getRefSchema(doc, schemaRef.Ref)
+	}
+
+	return schema != nil && schema.Type == openapi3.TypeArray
+}
+
+// hasParameter returns whether the parameter is already defined by name and location.
+func hasParameter(params openapi3.Parameters, in, name string) bool {
+	for _, param := range params {
+		if param.Value.In == in && param.Value.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -9,7 +9,14 @@ import (
 func (g *Generator) addDefaultResponse(doc *openapi3.T) error {
 	name := *g.config.DefaultResponse
 	if name == "" {
-		return nil
+		// Fall back to the standard error of the routing protocol.
+		var schema *openapi3.Schema
+		name, schema = g.getRoutingErrorSchema()
+		if name == "" {
+			return nil
+		}
+
+		doc.Components.Schemas[name] = schema.NewRef()
 	}
 
 	// TODO: allow for custom description?
@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const (
+	// routingTwirp routes unannotated methods to "POST /twirp/<package>.<Service>/<Method>".
+	routingTwirp = "twirp"
+	// routingConnect routes unannotated methods to "POST /<package>.<Service>/<Method>".
+	routingConnect = "connect"
+)
+
+var (
+	// twirpErrorCodes are the codes of a Twirp error.
+	// See https://twitchtv.github.io/twirp/docs/spec_v7.html#error-codes
+	twirpErrorCodes = []any{
+		"canceled", "unknown", "invalid_argument", "malformed", "deadline_exceeded", "not_found",
+		"bad_route", "already_exists", "permission_denied", "unauthenticated", "resource_exhausted",
+		"failed_precondition", "aborted", "out_of_range", "unimplemented", "internal", "unavailable",
+		"data_loss",
+	}
+	// connectErrorCodes are the codes of a Connect error.
+	// See https://connectrpc.com/docs/protocol#error-codes
+	connectErrorCodes = []any{
+		"canceled", "unknown", "invalid_argument", "deadline_exceeded", "not_found", "already_exists",
+		"permission_denied", "resource_exhausted", "failed_precondition", "aborted", "out_of_range",
+		"unimplemented", "internal", "unavailable", "data_loss", "unauthenticated",
+	}
+)
+
+// validateRouting returns an error if the configured routing isn't supported.
+func (g *Generator) validateRouting() error {
+	switch *g.config.Routing {
+	case "", routingTwirp, routingConnect:
+		return nil
+	default:
+		return fmt.Errorf("invalid routing '%s'", *g.config.Routing)
+	}
+}
+
+// newRoutingBinding returns the binding of the method for the configured routing or nil if there
+// is no routing. Streaming methods aren't a single request and response, so they're skipped.
+func (g *Generator) newRoutingBinding(method *protogen.Method) *httpBinding {
+	route := fmt.Sprintf("/%s/%s", method.Parent.Desc.FullName(), method.Desc.Name())
+
+	switch *g.config.Routing {
+	case routingTwirp:
+		route = "/twirp" + route
+	case routingConnect:
+	default:
+		return nil
+	}
+
+	if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+		g.warnAt(locate(method.Desc), "streaming method '%s' can't be routed with %s routing, so it's skipped", method.Desc.FullName(), *g.config.Routing)
+		return nil
+	}
+
+	return &httpBinding{
+		method: http.MethodPost,
+		path:   route,
+		body:   "*",
+	}
+}
+
+// getRoutingErrorSchema returns the name and schema of the standard error of the configured
+// routing or an empty name if there is no routing.
+func (g *Generator) getRoutingErrorSchema() (string, *openapi3.Schema) {
+	switch *g.config.Routing {
+	case routingTwirp:
+		return "twirp.Error", &openapi3.Schema{
+			Type:     openapi3.TypeObject,
+			Required: []string{"code", "msg"},
+			Properties: openapi3.Schemas{
+				"code": &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: openapi3.TypeString,
+						Enum: twirpErrorCodes,
+					},
+				},
+				"msg": &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: openapi3.TypeString,
+					},
+				},
+				"meta": &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: openapi3.TypeObject,
+						AdditionalProperties: openapi3.AdditionalProperties{
+							Schema: &openapi3.SchemaRef{
+								Value: &openapi3.Schema{
+									Type: openapi3.TypeString,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	case routingConnect:
+		return "connect.Error", &openapi3.Schema{
+			Type: openapi3.TypeObject,
+			Properties: openapi3.Schemas{
+				"code": &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: openapi3.TypeString,
+						Enum: connectErrorCodes,
+					},
+				},
+				"message": &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: openapi3.TypeString,
+					},
+				},
+				"details": &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: openapi3.TypeArray,
+						Items: &openapi3.SchemaRef{
+							Value: &openapi3.Schema{
+								Type: openapi3.TypeObject,
+								Properties: openapi3.Schemas{
+									"type": &openapi3.SchemaRef{
+										Value: &openapi3.Schema{
+											Type: openapi3.TypeString,
+										},
+									},
+									"value": &openapi3.SchemaRef{
+										Value: &openapi3.Schema{
+											Type:   openapi3.TypeString,
+											Format: "byte",
+										},
+									},
+									"debug": &openapi3.SchemaRef{
+										Value: &openapi3.Schema{
+											Type: openapi3.TypeObject,
+											AdditionalProperties: openapi3.AdditionalProperties{
+												Has: boolPtr(true),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	default:
+		return "", nil
+	}
+}
@@ -15,30 +15,21 @@ import (
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
-// addSchemasToDoc adds all non "request" and "response" messages as schemas to the OAPI doc.
-func (g *Generator) addSchemasToDoc(doc *openapi3.T, messages []*protogen.Message) error {
+// addSchemasToDoc adds all messages that are components as schemas to the OAPI doc. Which messages
+// are components is decided by the message options and the component strategy. Errors are reported
+// so the remaining messages are still checked.
+func (g *Generator) addSchemasToDoc(doc *openapi3.T, messages []*protogen.Message) {
 	for _, message := range messages {
-		messageName := util.FullName(message)
-
-		if util.IsRequestMessage(message) || util.IsResponseMessage(message) {
-			continue
-		}
-
-		messageSchemaRef := &openapi3.SchemaRef{
-			Value: &openapi3.Schema{
-				Properties: make(openapi3.Schemas),
-			},
-		}
-
-		err := g.buildSchema(doc, message, messageSchemaRef)
-		if err != nil {
-			return err
+		if g.isComponent(message) {
+			_, err := g.addMessageSchema(doc, message)
+			if err != nil {
+				g.report(locatedAt(message.Desc, err))
+			}
 		}
 
-		addSchema(doc, messageName, messageSchemaRef)
+		// Nested messages can be components through their options.
+		g.addSchemasToDoc(doc, message.Messages)
 	}
-
-	return nil
 }
 
 // getFieldName returns the raw field name or the JSON defined one if the config is set to use JSON
@@ -57,138 +48,339 @@ func (g *Generator) buildSchema(doc *openapi3.T, message *protogen.Message, pare
 		parent.Value.Required = make([]string, 0)
 	}
 
-	for _, field := range message.Fields {
-		// Use the JSON name if defined.
-		fieldName := g.getFieldName(field)
+	// Track the message while it's being built so references back to it aren't built forever.
+	messageName := util.FullName(message)
+	g.building[messageName]++
+	defer func() {
+		g.building[messageName]--
+	}()
 
-		fieldSchemaRef := &openapi3.SchemaRef{
-			Value: newFieldSchema(field.Desc),
+	for _, field := range message.Fields {
+		// Fields of a oneof are added with the oneof below.
+		if isOneofField(field) {
+			continue
 		}
-		parsed := g.parseComments(field.Comments.Leading)
-		fieldSchemaRef.Value.Description = parsed.Description
-
-		// Apply example. This can be overridden below on the example option.
-		if parsed.Example != "" {
-			var example any
-			exampleBytes := []byte(parsed.Example)
-
-			if json.Valid(exampleBytes) {
-				if err := json.Unmarshal(exampleBytes, &example); err != nil {
-					return err
-				}
-			} else {
-				example = parsed.Example
-			}
 
-			fieldSchemaRef.Value.Example = example
+		err := g.addFieldSchema(doc, message, field, parent)
+		if err != nil {
+			return locatedAt(field.Desc, err)
 		}
+	}
 
-		// Deprecated option.
-		if standardOptions, ok := field.Desc.Options().(*descriptorpb.FieldOptions); ok {
-			fieldSchemaRef.Value.Deprecated = standardOptions.GetDeprecated()
+	oneofSchemas := make([]*openapi3.Schema, 0)
+
+	for _, oneof := range message.Oneofs {
+		// Synthetic oneofs are from proto3 optional fields which are regular properties.
+		if oneof.Desc.IsSynthetic() {
+			continue
 		}
 
-		// Required option.
-		extRequired := proto.GetExtension(field.Desc.Options(), oapiv1.E_Required)
-		if extRequired != nil && extRequired != oapiv1.E_Required.InterfaceOf(oapiv1.E_Required.Zero()) {
-			parent.Value.Required = append(parent.Value.Required, fieldName)
+		oneofSchema, err := g.buildOneofSchema(doc, message, oneof)
+		if err != nil {
+			return locatedAt(oneof.Desc, err)
 		}
 
-		// Example option.
-		extExample := proto.GetExtension(field.Desc.Options(), oapiv1.E_Example)
-		if extExample != nil && extExample != oapiv1.E_Example.InterfaceOf(oapiv1.E_Example.Zero()) {
-			parent.Value.Description = *extExample.(*string)
+		oneofSchemas = append(oneofSchemas, oneofSchema)
+	}
+
+	switch len(oneofSchemas) {
+	case 0:
+	case 1:
+		parent.Value.OneOf = oneofSchemas[0].OneOf
+	default:
+		// Each oneof has to be satisfied on its own.
+		for _, oneofSchema := range oneofSchemas {
+			parent.Value.AllOf = append(parent.Value.AllOf, oneofSchema.NewRef())
 		}
+	}
 
-		// Field options.
-		err := g.setSchemaProperties(fieldSchemaRef.Value, parent.Value, field)
+	if g.config.SchemaHook != nil {
+		err := g.config.SchemaHook(message, parent.Value)
 		if err != nil {
-			return err
+			return locatedAt(message.Desc, err)
 		}
+	}
 
-		// TODO: Figure out how to merge the following 2 logical pieces together so most of it isn't
-		// duplicated.
-		if fieldSchemaRef.Value.Type == openapi3.TypeObject {
-			fieldMessageName := util.FullName(field.Message)
-
-			var isChild bool
-			// Here we look for child messages first in case the name is the same as a top level
-			// name.
-			for _, childMessage := range message.Messages {
-				if field.Message.Desc.FullName() == childMessage.Desc.FullName() {
-					isChild = true
-					err := g.buildSchema(doc, childMessage, fieldSchemaRef)
-					if err != nil {
-						return err
-					}
-				}
-			}
+	return nil
+}
 
-			if !isChild {
-				// If it's not a child message, it should exist in schemas, and we can then just do
-				// a ref...
-				if schemaExists(doc, fieldMessageName) {
-					fieldSchemaRef.Ref = newSchemaRef(fieldMessageName)
-				} else {
-					// If it doesn't exist in schemas, then it's referenced elsewhere. We'll try to
-					// snag it from our message map.
-					msg := allMessages.Get(fieldMessageName)
-					if msg != nil {
-						// Use the message to build it out inline instead of using a ref.
-						err := g.buildSchema(doc, msg, fieldSchemaRef)
-						if err != nil {
-							return err
-						}
-					} else {
-						return fmt.Errorf("'%s' references '%s' but it seems to be missing", field.Desc.FullName(), fieldMessageName)
-					}
-				}
+// addFieldSchema builds out the schema for a field and adds it to the properties of the parent.
+func (g *Generator) addFieldSchema(doc *openapi3.T, message *protogen.Message, field *protogen.Field, parent *openapi3.SchemaRef) error {
+	// Use the JSON name if defined.
+	fieldName := g.getFieldName(field)
+
+	fieldSchemaRef := &openapi3.SchemaRef{
+		Value: newFieldSchema(field.Desc),
+	}
+	parsed := g.parseComments(field.Comments.Leading)
+	fieldSchemaRef.Value.Description = parsed.Description
+
+	// Apply example. This can be overridden below on the example option.
+	if parsed.Example != "" {
+		var example any
+		exampleBytes := []byte(parsed.Example)
+
+		if json.Valid(exampleBytes) {
+			if err := json.Unmarshal(exampleBytes, &example); err != nil {
+				return err
 			}
+		} else {
+			example = parsed.Example
 		}
 
-		if fieldSchemaRef.Value.Type == openapi3.TypeArray && fieldSchemaRef.Value.Items.Value.Type == "object" {
-			// Array of objects to build out
-			fieldMessageName := util.FullName(field.Message)
-
-			var isChild bool
-			for _, childMessage := range message.Messages {
-				if field.Message.Desc.FullName() == childMessage.Desc.FullName() {
-					isChild = true
-					err := g.buildSchema(doc, childMessage, fieldSchemaRef.Value.Items)
-					if err != nil {
-						return err
-					}
-				}
-			}
+		fieldSchemaRef.Value.Example = example
+	}
+
+	// Deprecated option.
+	if standardOptions, ok := field.Desc.Options().(*descriptorpb.FieldOptions); ok {
+		fieldSchemaRef.Value.Deprecated = standardOptions.GetDeprecated()
+	}
+
+	// Required option.
+	extRequired := proto.GetExtension(field.Desc.Options(), oapiv1.E_Required)
+	if extRequired != nil && extRequired != oapiv1.E_Required.InterfaceOf(oapiv1.E_Required.Zero()) {
+		parent.Value.Required = append(parent.Value.Required, fieldName)
+	}
+
+	// Example option.
+	extExample := proto.GetExtension(field.Desc.Options(), oapiv1.E_Example)
+	if extExample != nil && extExample != oapiv1.E_Example.InterfaceOf(oapiv1.E_Example.Zero()) {
+		parent.Value.Description = *extExample.(*string)
+	}
+
+	// Field options.
+	err := g.setSchemaProperties(fieldSchemaRef.Value, parent.Value, field)
+	if err != nil {
+		return err
+	}
+
+	// Enums are referenced from the component schemas.
+	if field.Enum != nil {
+		enumRef := g.addEnumSchema(doc, field.Enum)
+
+		if fieldSchemaRef.Value.Type == openapi3.TypeArray {
+			fieldSchemaRef.Value.Items.Ref = enumRef
+		} else {
+			fieldSchemaRef.Ref = enumRef
+		}
+	}
+
+	switch {
+	case field.Message != nil && isWellKnownType(field.Message):
+		// Well-known types are already represented by their protojson schema.
+	case field.Desc.IsMap():
+		// Map values are referenced from the component schemas unless the message is never a
+		// component.
+		valueField := field.Message.Fields[1]
+		valueSchemaRef := fieldSchemaRef.Value.AdditionalProperties.Schema
+
+		if valueField.Enum != nil {
+			valueSchemaRef.Ref = g.addEnumSchema(doc, valueField.Enum)
+		}
 
-			if !isChild {
-				// If it's not a child message, it should exist in schemas, and we can then just do
-				// a ref...
-				if schemaExists(doc, fieldMessageName) {
-					fieldSchemaRef.Ref = newSchemaRef(fieldMessageName)
-				} else {
-					// If it doesn't exist in schemas, then it's referenced elsewhere. We'll try to
-					// snag it from our message map.
-					msg := allMessages.Get(fieldMessageName)
-					if msg != nil {
-						// Use the message to build it out inline instead of using a ref.
-						err := g.buildSchema(doc, msg, fieldSchemaRef.Value.Items)
-						if err != nil {
-							return err
-						}
-					} else {
-						return fmt.Errorf("'%s' references '%s' but it seems to be missing", field.Desc.FullName(), fieldMessageName)
-					}
-				}
+		if valueField.Message != nil && !isWellKnownType(valueField.Message) {
+			component := getMessageOptions(valueField.Message).Component
+
+			if component != nil && !*component && g.building[util.FullName(valueField.Message)] == 0 {
+				// Inline only if the message says so.
+				err = g.buildSchema(doc, valueField.Message, valueSchemaRef)
+			} else {
+				valueSchemaRef.Ref, err = g.addMessageSchema(doc, valueField.Message)
+			}
+			if err != nil {
+				return err
 			}
 		}
+	case fieldSchemaRef.Value.Type == openapi3.TypeObject:
+		err := g.buildFieldMessageSchema(doc, message, field, fieldSchemaRef)
+		if err != nil {
+			return err
+		}
+	case fieldSchemaRef.Value.Type == openapi3.TypeArray && field.Message != nil:
+		// Array of objects to build out
+		err := g.buildFieldMessageSchema(doc, message, field, fieldSchemaRef.Value.Items)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Proto3 optional fields may be omitted or null.
+	if field.Desc.HasOptionalKeyword() {
+		fieldSchemaRef.Value.Nullable = true
+	}
 
-		parent.Value.Properties[fieldName] = fieldSchemaRef
+	if _, ok := parent.Value.Properties[fieldName]; !ok {
+		g.propertyOrders[parent.Value] = append(g.propertyOrders[parent.Value], fieldName)
 	}
 
+	parent.Value.Properties[fieldName] = fieldSchemaRef
+
 	return nil
 }
 
+// buildFieldSchemaRef builds out the schema for a field on its own so the same options apply as in
+// messages. It also returns whether the field is required.
+func (g *Generator) buildFieldSchemaRef(doc *openapi3.T, field *protogen.Field) (*openapi3.SchemaRef, bool, error) {
+	parent := &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Properties: make(openapi3.Schemas),
+			Required:   make([]string, 0),
+		},
+	}
+
+	err := g.addFieldSchema(doc, field.Parent, field, parent)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return parent.Value.Properties[g.getFieldName(field)], len(parent.Value.Required) > 0, nil
+}
+
+// buildOneofSchema returns a schema with a "oneOf" of each field in the oneof and of none of them.
+// Each field schema requires that field, so only one of them can be set.
+func (g *Generator) buildOneofSchema(doc *openapi3.T, message *protogen.Message, oneof *protogen.Oneof) (*openapi3.Schema, error) {
+	oneofOptions := new(oapiv1.OneofOptions)
+
+	extOneof := proto.GetExtension(oneof.Desc.Options(), oapiv1.E_Oneof)
+	if extOneof != nil && extOneof != oapiv1.E_Oneof.InterfaceOf(oapiv1.E_Oneof.Zero()) {
+		oneofOptions = extOneof.(*oapiv1.OneofOptions)
+	}
+
+	schema := &openapi3.Schema{
+		OneOf: make(openapi3.SchemaRefs, 0, len(oneof.Fields)),
+	}
+
+	for _, field := range oneof.Fields {
+		fieldSchemaRef := &openapi3.SchemaRef{
+			Value: &openapi3.Schema{
+				Type:       openapi3.TypeObject,
+				Properties: make(openapi3.Schemas),
+				Required:   []string{g.getFieldName(field)},
+			},
+		}
+
+		err := g.addFieldSchema(doc, message, field, fieldSchemaRef)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.OneOf = append(schema.OneOf, fieldSchemaRef)
+	}
+
+	// None of the fields may be set either, which is the only alternative without any of them.
+	none := &openapi3.Schema{
+		AnyOf: make(openapi3.SchemaRefs, 0, len(oneof.Fields)),
+	}
+
+	for _, field := range oneof.Fields {
+		none.AnyOf = append(none.AnyOf, (&openapi3.Schema{Required: []string{g.getFieldName(field)}}).NewRef())
+	}
+
+	schema.OneOf = append(schema.OneOf, (&openapi3.Schema{Not: none.NewRef()}).NewRef())
+
+	discriminator := strings.TrimSpace(oneofOptions.Discriminator)
+	if discriminator != "" {
+		_, err := g.findDiscriminatorField(message, discriminator)
+		if err != nil {
+			return nil, errorAt(locateOption(oneof.Desc, "discriminator"), "%s", err.Error())
+		}
+
+		// A discriminator picks between referenced component schemas by their names or its mapping.
+		// The alternatives of a oneof are inline objects that wrap its fields, so there's nothing it
+		// could pick and it's left out.
+		g.warnAt(locateOption(oneof.Desc, "discriminator"), "discriminator '%s' of oneof '%s' is left out since its alternatives aren't component schemas", discriminator, oneof.Desc.FullName())
+	}
+
+	return schema, nil
+}
+
+// findDiscriminatorField returns the field of the message named by the discriminator of a oneof.
+// protojson only writes the fields of the message, so the discriminator has to be one of them. It
+// has to be a string or enum that isn't part of a oneof itself.
+func (g *Generator) findDiscriminatorField(message *protogen.Message, discriminator string) (*protogen.Field, error) {
+	for _, field := range message.Fields {
+		if string(field.Desc.Name()) != discriminator && field.Desc.JSONName() != discriminator {
+			continue
+		}
+
+		if isOneofField(field) || field.Desc.IsList() ||
+			(field.Desc.Kind() != protoreflect.StringKind && field.Desc.Kind() != protoreflect.EnumKind) {
+			return nil, fmt.Errorf("discriminator '%s' must be a string or enum field outside of a oneof", discriminator)
+		}
+
+		return field, nil
+	}
+
+	return nil, fmt.Errorf("discriminator '%s' isn't a field of message '%s'", discriminator, message.Desc.FullName())
+}
+
+// isOneofField returns whether the field is part of a oneof that isn't synthetic.
+func isOneofField(field *protogen.Field) bool {
+	return field.Oneof != nil && !field.Oneof.Desc.IsSynthetic()
+}
+
+// buildFieldMessageSchema builds out the schema for the message of a field. Components, messages
+// that already exist in the schemas and messages that are already being built are referenced.
+// Child messages are built inline and anything else is built inline from the message map.
+func (g *Generator) buildFieldMessageSchema(doc *openapi3.T, message *protogen.Message, field *protogen.Field, ref *openapi3.SchemaRef) error {
+	fieldMessageName := util.FullName(field.Message)
+
+	// If the message is already being built, it's recursive and can only be referenced. Either way,
+	// it's added to the schemas if it isn't there yet.
+	if g.building[fieldMessageName] > 0 || g.isComponent(field.Message) || schemaExists(doc, schemaName(field.Message)) {
+		var err error
+		ref.Ref, err = g.addMessageSchema(doc, field.Message)
+		return err
+	}
+
+	// Here we look for child messages first in case the name is the same as a top level name.
+	for _, childMessage := range message.Messages {
+		if field.Message.Desc.FullName() == childMessage.Desc.FullName() {
+			return g.buildSchema(doc, childMessage, ref)
+		}
+	}
+
+	// If it's not a child message, it's referenced elsewhere. We'll try to snag it from our message
+	// map.
+	msg := g.messages.Get(fieldMessageName)
+	if msg == nil {
+		return fmt.Errorf("'%s' references '%s' but it seems to be missing", field.Desc.FullName(), fieldMessageName)
+	}
+
+	// Use the message to build it out inline instead of using a ref.
+	return g.buildSchema(doc, msg, ref)
+}
+
+// addMessageSchema adds the message as a schema to the OAPI doc if it isn't already there and
+// returns the reference to it. The schema is added before it's built so messages that reference
+// themselves resolve to the same schema.
+func (g *Generator) addMessageSchema(doc *openapi3.T, message *protogen.Message) (string, error) {
+	name := schemaName(message)
+
+	// Options can give messages the same name, which would replace each other's schema.
+	if source := g.schemaSources[name]; source != nil && source.Desc.FullName() != message.Desc.FullName() {
+		return "", errorAt(locateOption(message.Desc, "name"), "schema name '%s' of message '%s' is already used by message '%s'", name, message.Desc.FullName(), source.Desc.FullName())
+	}
+
+	if !schemaExists(doc, name) {
+		messageSchemaRef := &openapi3.SchemaRef{
+			Value: &openapi3.Schema{
+				Properties: make(openapi3.Schemas),
+			},
+		}
+
+		addSchema(doc, name, messageSchemaRef)
+		g.schemaSources[name] = message
+
+		err := g.buildSchema(doc, message, messageSchemaRef)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return newSchemaRef(name), nil
+}
+
 // addSchema adds the specified schema to the OAPI doc.
 func addSchema(doc *openapi3.T, key string, value *openapi3.SchemaRef) {
 	doc.Components.Schemas[key] = value
@@ -200,6 +392,22 @@ func schemaExists(doc *openapi3.T, name string) bool {
 	return ok
 }
 
+// getRefSchema returns the component schema the reference is to or nil if it isn't to one of the
+// schemas of the doc.
+func getRefSchema(doc *openapi3.T, ref string) *openapi3.Schema {
+	name := strings.TrimPrefix(ref, schemaRefPrefix)
+	if name == ref {
+		return nil
+	}
+
+	schemaRef := doc.Components.Schemas[name]
+	if schemaRef == nil {
+		return nil
+	}
+
+	return schemaRef.Value
+}
+
 // newArraySchema returns a new schema for an array of the specified kind.
 func newArraySchema(kind protoreflect.Kind) *openapi3.Schema {
 	return &openapi3.Schema{
@@ -222,6 +430,13 @@ func newSchemaRef(name string) string {
 // newFieldSchema returns a new OAPI represented schema for protobuf types on fields.
 func newFieldSchema(field protoreflect.FieldDescriptor) *openapi3.Schema {
 	kind := field.Kind()
+
+	if kind == protoreflect.MessageKind && !field.IsList() && !field.IsMap() {
+		if wellKnownSchema := newWellKnownSchema(field.Message().FullName()); wellKnownSchema != nil {
+			return wellKnownSchema
+		}
+	}
+
 	schema := &openapi3.Schema{
 		Type:       protoKindToAPIType(kind),
 		Properties: make(openapi3.Schemas),
@@ -229,16 +444,58 @@ func newFieldSchema(field protoreflect.FieldDescriptor) *openapi3.Schema {
 
 	if field.IsList() {
 		schema.Type = openapi3.TypeArray
-		return newArraySchema(kind)
+		arraySchema := newArraySchema(kind)
+
+		if kind == protoreflect.MessageKind {
+			if wellKnownSchema := newWellKnownSchema(field.Message().FullName()); wellKnownSchema != nil {
+				arraySchema.Items.Value = wellKnownSchema
+			}
+		}
+
+		return arraySchema
+	}
+
+	if field.IsMap() {
+		// Maps are objects in JSON, so the keys are strings whatever their type is.
+		schema.AdditionalProperties = openapi3.AdditionalProperties{
+			Schema: &openapi3.SchemaRef{
+				Value: newFieldSchema(field.MapValue()),
+			},
+		}
+
+		// OpenAPI 3.0 has no propertyNames, so the pattern of the keys is an extension that's
+		// converted to propertyNames for 3.1.
+		if pattern := getMapKeyPattern(field.MapKey().Kind()); pattern != "" {
+			schema.Extensions = map[string]any{
+				"x-propertyNames": map[string]any{"pattern": pattern},
+			}
+		}
 	}
 
 	return schema
 }
 
+// getMapKeyPattern returns the pattern that map keys of the kind match as JSON property names or
+// an empty string for string keys.
+func getMapKeyPattern(kind protoreflect.Kind) string {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "^-?[0-9]+$"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "^[0-9]+$"
+	case protoreflect.BoolKind:
+		return "^(true|false)$"
+	default:
+		return ""
+	}
+}
+
 // protoKindToAPIType returns an OAPI type based on the proto kind sent.
 func protoKindToAPIType(kind protoreflect.Kind) string {
 	switch kind {
 	case protoreflect.StringKind,
+		protoreflect.EnumKind,
 		protoreflect.Int64Kind,
 		protoreflect.Uint64Kind,
 		protoreflect.Sint64Kind:
@@ -340,7 +597,37 @@ func (g *Generator) setSchemaProperties(s *openapi3.Schema, parent *openapi3.Sch
 
 	fo := extOptions.(*oapiv1.FieldOptions)
 
-	return setProperties(s, fo, func() {
+	// OpenAPI 3.1 only has the number form of exclusiveMinimum and exclusiveMaximum, which is the
+	// bound itself, so the booleans can't be written without one.
+	if *g.config.OpenAPIVersion == openAPIVersion31 {
+		if fo.GetExclusiveMin() && fo.Min == nil {
+			return errorAt(locateOption(field.Desc, "exclusive_min"), "exclusive_min of field '%s' needs min with openapi_version 3.1", field.Desc.FullName())
+		}
+
+		if fo.GetExclusiveMax() && fo.Max == nil {
+			return errorAt(locateOption(field.Desc, "exclusive_max"), "exclusive_max of field '%s' needs max with openapi_version 3.1", field.Desc.FullName())
+		}
+	}
+
+	requiredFn := func() {
 		parent.Required = append(parent.Required, g.getFieldName(field))
-	})
+	}
+
+	if field.Desc.IsMap() {
+		// Map options apply to the values except for the amount of properties which is on the map
+		// itself.
+		valueSchema := s.AdditionalProperties.Schema.Value
+
+		err := setProperties(valueSchema, fo, requiredFn)
+		if err != nil {
+			return err
+		}
+
+		s.MinProps, valueSchema.MinProps = valueSchema.MinProps, 0
+		s.MaxProps, valueSchema.MaxProps = valueSchema.MaxProps, nil
+
+		return nil
+	}
+
+	return setProperties(s, fo, requiredFn)
 }
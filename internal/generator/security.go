@@ -0,0 +1,256 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	oapiv1 "github.com/technicallyjosh/protoc-gen-openapi/api/oapi/v1"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// securitySchemeTypeOAuth2 is the type of security schemes with flows and the scopes they declare.
+const securitySchemeTypeOAuth2 = "oauth2"
+
+// securitySchemeDeclaration is a security scheme along with the file that declares it.
+type securitySchemeDeclaration struct {
+	file   *protogen.File
+	scheme *openapi3.SecurityScheme
+}
+
+// buildSecuritySchemes collects the security schemes of all files by name, including the files that
+// are only imported, so security requirements can be resolved against the files they're visible
+// from. Invalid schemes are reported at their option.
+func (g *Generator) buildSecuritySchemes() {
+	g.securitySchemes = make(map[string][]*securitySchemeDeclaration)
+
+	for _, file := range g.plugin.Files {
+		extFile := proto.GetExtension(file.Desc.Options(), oapiv1.E_File)
+		if extFile == nil || extFile == oapiv1.E_File.InterfaceOf(oapiv1.E_File.Zero()) {
+			continue
+		}
+
+		fileOptions := extFile.(*oapiv1.FileOptions)
+
+		for i, scheme := range fileOptions.SecuritySchemes {
+			securityScheme := &openapi3.SecurityScheme{
+				Type:             scheme.Scheme.GetType(),
+				Description:      scheme.Scheme.GetDescription(),
+				Name:             scheme.Scheme.GetName(),
+				In:               scheme.Scheme.GetIn(),
+				Scheme:           scheme.Scheme.GetScheme(),
+				BearerFormat:     scheme.Scheme.GetBearerFormat(),
+				OpenIdConnectUrl: scheme.Scheme.GetOpenIdConnectUrl(),
+				Flows:            newOAuthFlows(scheme.Scheme.GetFlows()),
+			}
+
+			err := securityScheme.Validate(context.Background())
+			if err != nil {
+				g.report(errorAt(locateOption(file.Desc, "security_schemes", i, "scheme"), "security scheme '%s' is invalid: %v", scheme.Name, err))
+			}
+
+			g.securitySchemes[scheme.Name] = append(g.securitySchemes[scheme.Name], &securitySchemeDeclaration{
+				file:   file,
+				scheme: securityScheme,
+			})
+		}
+	}
+}
+
+// addFileSecurityToDoc adds the security schemes and the default security of the file to the doc.
+func (g *Generator) addFileSecurityToDoc(doc *openapi3.T, file *protogen.File) {
+	extFile := proto.GetExtension(file.Desc.Options(), oapiv1.E_File)
+
+	if extFile != nil && extFile != oapiv1.E_File.InterfaceOf(oapiv1.E_File.Zero()) {
+		fileOptions := extFile.(*oapiv1.FileOptions)
+
+		for _, scheme := range fileOptions.SecuritySchemes {
+			for _, declaration := range g.securitySchemes[scheme.Name] {
+				if declaration.file == file {
+					doc.Components.SecuritySchemes[scheme.Name] = &openapi3.SecuritySchemeRef{
+						Value: declaration.scheme,
+					}
+				}
+			}
+		}
+
+		g.resolveSecurity(doc, file.Desc, fileOptions.Security)
+
+		requirements := make([]openapi3.SecurityRequirement, 0)
+		for _, sec := range fileOptions.Security {
+			// Requirements without scopes have an empty list of them.
+			scopes := append(make([]string, 0, len(sec.Scopes)), sec.Scopes...)
+
+			requirements = append(requirements, openapi3.SecurityRequirement{
+				sec.Name: scopes,
+			})
+		}
+
+		doc.Security = requirements
+	}
+}
+
+// newOAuthFlows returns the OAuth flows of the options or nil if there are none.
+func newOAuthFlows(flows *oapiv1.OAuthFlows) *openapi3.OAuthFlows {
+	if flows == nil {
+		return nil
+	}
+
+	return &openapi3.OAuthFlows{
+		Implicit:          newOAuthFlow(flows.Implicit),
+		Password:          newOAuthFlow(flows.Password),
+		ClientCredentials: newOAuthFlow(flows.ClientCredentials),
+		AuthorizationCode: newOAuthFlow(flows.AuthorizationCode),
+	}
+}
+
+// newOAuthFlow returns the OAuth flow of the options or nil if it isn't set.
+func newOAuthFlow(flow *oapiv1.OAuthFlow) *openapi3.OAuthFlow {
+	if flow == nil {
+		return nil
+	}
+
+	// Scopes are required even if there are none.
+	scopes := make(map[string]string, len(flow.Scopes))
+	for name, description := range flow.Scopes {
+		scopes[name] = description
+	}
+
+	return &openapi3.OAuthFlow{
+		AuthorizationURL: flow.AuthorizationUrl,
+		TokenURL:         flow.TokenUrl,
+		RefreshURL:       flow.RefreshUrl,
+		Scopes:           scopes,
+	}
+}
+
+// resolveSecurity resolves the schemes of the security of a file, service or method and adds the
+// ones declared in other files to the doc. Schemes that aren't visible from the file of the
+// descriptor and scopes their oauth2 scheme doesn't declare are reported.
+func (g *Generator) resolveSecurity(doc *openapi3.T, desc protoreflect.Descriptor, security []*oapiv1.Security) {
+	for i, sec := range security {
+		// An empty one clears the security on an override.
+		if sec.Name == "" {
+			continue
+		}
+
+		declaration, err := g.findSecurityScheme(desc.ParentFile(), sec.Name)
+		if err != nil {
+			g.report(errorAt(locateOption(desc, "security", i, "name"), "%s", err.Error()))
+			continue
+		}
+
+		if _, ok := doc.Components.SecuritySchemes[sec.Name]; !ok {
+			doc.Components.SecuritySchemes[sec.Name] = &openapi3.SecuritySchemeRef{
+				Value: declaration.scheme,
+			}
+		}
+
+		// Other types of schemes don't declare their scopes.
+		flows := declaration.scheme.Flows
+		if declaration.scheme.Type != securitySchemeTypeOAuth2 || flows == nil {
+			continue
+		}
+
+		declared := make(map[string]bool)
+
+		for _, flow := range []*openapi3.OAuthFlow{flows.Implicit, flows.Password, flows.ClientCredentials, flows.AuthorizationCode} {
+			if flow == nil {
+				continue
+			}
+
+			for scope := range flow.Scopes {
+				declared[scope] = true
+			}
+		}
+
+		for j, scope := range sec.Scopes {
+			if !declared[scope] {
+				g.report(errorAt(locateOption(desc, "security", i, "scopes", j), "scope '%s' isn't declared by security scheme '%s'", scope, sec.Name))
+			}
+		}
+	}
+}
+
+// findSecurityScheme returns the declaration of the security scheme that's visible from the file.
+// Schemes are visible from the file that declares them and the files that import it.
+func (g *Generator) findSecurityScheme(file protoreflect.FileDescriptor, name string) (*securitySchemeDeclaration, error) {
+	declarations := g.securitySchemes[name]
+	if len(declarations) == 0 {
+		return nil, fmt.Errorf("security scheme '%s' isn't declared", name)
+	}
+
+	for _, declaration := range declarations {
+		if declaration.file.Desc.Path() == file.Path() || importsFile(file, declaration.file.Desc.Path(), make(map[string]bool)) {
+			return declaration, nil
+		}
+	}
+
+	return nil, fmt.Errorf("security scheme '%s' is declared in '%s', which '%s' doesn't import", name, declarations[0].file.Desc.Path(), file.Path())
+}
+
+// importsFile returns whether the file imports the file at the path, directly or through other
+// imports.
+func importsFile(file protoreflect.FileDescriptor, path string, visited map[string]bool) bool {
+	imports := file.Imports()
+
+	for i := 0; i < imports.Len(); i++ {
+		imported := imports.Get(i).FileDescriptor
+		if visited[imported.Path()] {
+			continue
+		}
+		visited[imported.Path()] = true
+
+		if imported.Path() == path || importsFile(imported, path, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getSecurityMode returns the mode of the security of a service or method. Security without a mode
+// uses the legacy mode and an empty one, e.g. security: {}, the clear mode. Security that an
+// inheriting mode or no security at all would ignore is reported.
+func (g *Generator) getSecurityMode(desc protoreflect.Descriptor, mode oapiv1.SecurityMode, security []*oapiv1.Security, legacyMode, clearMode oapiv1.SecurityMode) oapiv1.SecurityMode {
+	switch mode {
+	case oapiv1.SecurityMode_SECURITY_MODE_UNSPECIFIED:
+		if len(security) == 0 {
+			return oapiv1.SecurityMode_SECURITY_MODE_INHERIT
+		}
+
+		for _, sec := range security {
+			if sec.Name == "" {
+				return clearMode
+			}
+		}
+
+		return legacyMode
+	case oapiv1.SecurityMode_SECURITY_MODE_INHERIT, oapiv1.SecurityMode_SECURITY_MODE_NONE:
+		if len(security) > 0 {
+			g.report(errorAt(locateOption(desc, "security_mode"), "security can't be set with security_mode %s", mode))
+		}
+	}
+
+	return mode
+}
+
+// applySecurityMode returns the security of a service or method in the mode. Nil inherits the
+// security of the document and empty requires none.
+func applySecurityMode(mode oapiv1.SecurityMode, security, inherited, appendTo []*oapiv1.Security) []*oapiv1.Security {
+	switch mode {
+	case oapiv1.SecurityMode_SECURITY_MODE_REPLACE:
+		return append(make([]*oapiv1.Security, 0, len(security)), security...)
+	case oapiv1.SecurityMode_SECURITY_MODE_APPEND:
+		combined := make([]*oapiv1.Security, 0, len(appendTo)+len(security))
+		combined = append(combined, appendTo...)
+
+		return append(combined, security...)
+	case oapiv1.SecurityMode_SECURITY_MODE_NONE:
+		return make([]*oapiv1.Security, 0)
+	default:
+		return inherited
+	}
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// outputFormatOpenAPI writes an OpenAPI 3 document.
+	outputFormatOpenAPI = "openapi"
+	// outputFormatSwagger2 writes a Swagger 2.0 document converted from the OpenAPI 3 document.
+	outputFormatSwagger2 = "swagger2"
+)
+
+// validateOutputFormat returns an error if the configured output format isn't supported or can't
+// be combined with the OpenAPI version.
+func (g *Generator) validateOutputFormat() error {
+	switch *g.config.OutputFormat {
+	case outputFormatOpenAPI:
+		return nil
+	case outputFormatSwagger2:
+		if *g.config.OpenAPIVersion != openAPIVersion30 {
+			return fmt.Errorf("output_format '%s' requires openapi_version %s", outputFormatSwagger2, openAPIVersion30)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("invalid output_format '%s'", *g.config.OutputFormat)
+	}
+}
+
+// convertToSwagger2 converts the document to Swagger 2.0. Whatever can't be expressed in 2.0 is
+// removed from the document first with a warning for each so nothing is dropped silently.
+func (g *Generator) convertToSwagger2(doc *openapi3.T) (*openapi2.T, error) {
+	if len(doc.Servers) > 1 {
+		g.warn("Swagger 2.0 has a single host, so only server '%s' is kept out of %d", doc.Servers[0].URL, len(doc.Servers))
+	}
+
+	if len(g.webhooks) > 0 {
+		g.warn("Swagger 2.0 doesn't support webhooks, so %d are dropped", len(g.webhooks))
+	}
+
+	for _, pathItem := range doc.Paths {
+		for _, op := range pathItem.Operations() {
+			if op.Servers != nil && len(*op.Servers) > 0 {
+				g.warnAt(g.locateOperation(op), "Swagger 2.0 doesn't support servers on operations, so the servers of operation '%s' are dropped", op.OperationID)
+			}
+			op.Servers = nil
+
+			params := make(openapi3.Parameters, 0, len(op.Parameters))
+			for _, paramRef := range op.Parameters {
+				if paramRef.Value != nil && paramRef.Value.In == openapi3.ParameterInCookie {
+					g.warnAt(g.locateOperation(op), "Swagger 2.0 doesn't support cookie parameters, so parameter '%s' of operation '%s' is dropped", paramRef.Value.Name, op.OperationID)
+					continue
+				}
+
+				params = append(params, paramRef)
+			}
+			op.Parameters = params
+		}
+	}
+
+	visited := make(map[*openapi3.Schema]bool)
+	walkDocumentSchemas(doc, nil, func(location string, schemaRef *openapi3.SchemaRef) {
+		g.removeSwagger2Unsupported(location, schemaRef, visited)
+	})
+
+	return openapi2conv.FromV3(doc)
+}
+
+// removeSwagger2Unsupported removes the keywords of the schema and its subschemas that Swagger 2.0
+// doesn't support.
+func (g *Generator) removeSwagger2Unsupported(location string, schemaRef *openapi3.SchemaRef, visited map[*openapi3.Schema]bool) {
+	if schemaRef == nil || schemaRef.Ref != "" || schemaRef.Value == nil {
+		return
+	}
+
+	schema := schemaRef.Value
+
+	if visited[schema] {
+		return
+	}
+	visited[schema] = true
+
+	// The conversion sets "x-nullable" for nullable schemas on the extensions.
+	if schema.Extensions == nil {
+		schema.Extensions = make(map[string]any)
+	}
+
+	if len(schema.OneOf) > 0 {
+		g.warn("Swagger 2.0 doesn't support oneOf, so it's dropped from %s", location)
+		schema.OneOf = nil
+	}
+
+	if len(schema.AnyOf) > 0 {
+		g.warn("Swagger 2.0 doesn't support anyOf, so it's dropped from %s", location)
+		schema.AnyOf = nil
+	}
+
+	if schema.Discriminator != nil {
+		g.warn("Swagger 2.0 doesn't support discriminator objects, so it's dropped from %s", location)
+		schema.Discriminator = nil
+	}
+
+	for _, propertyRef := range schema.Properties {
+		g.removeSwagger2Unsupported(location, propertyRef, visited)
+	}
+
+	for _, subSchemaRef := range schema.AllOf {
+		g.removeSwagger2Unsupported(location, subSchemaRef, visited)
+	}
+
+	g.removeSwagger2Unsupported(location, schema.Items, visited)
+	g.removeSwagger2Unsupported(location, schema.AdditionalProperties.Schema, visited)
+}
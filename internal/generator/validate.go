@@ -0,0 +1,547 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"google.golang.org/protobuf/compiler/protogen"
+	"gopkg.in/yaml.v3"
+)
+
+// validateDocument checks the document once it's built and merged with the base file, before it's
+// converted and written. Errors are reported at the proto element they come from and generation
+// stops once all of them are. Examples that don't match their schema are only warnings. Each part
+// of the document is validated once: the component schemas, the operations and then the rest of
+// the document without them.
+func (g *Generator) validateDocument(doc *openapi3.T) error {
+	g.checkOperationIDs(doc)
+
+	if !g.checkSchemaRefs(doc) {
+		// The document can't be validated with unresolved references.
+		return g.checkReported()
+	}
+
+	restore := g.stubRefs(doc)
+	defer restore()
+
+	ctx := openapi3.WithValidationOptions(context.Background(), openapi3.DisableExamplesValidation())
+
+	g.validateComponentSchemas(ctx, doc)
+	g.validateOperations(ctx, doc)
+	g.checkExamples(doc)
+
+	err := g.checkReported()
+	if err != nil {
+		return err
+	}
+
+	// Anything not tied to a single element, such as conflicting paths, is left to the document.
+	err = validateDocumentRest(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("generated document is invalid: %w", err)
+	}
+
+	return nil
+}
+
+// checkOperationIDs reports each operation with the operation ID of another one.
+func (g *Generator) checkOperationIDs(doc *openapi3.T) {
+	methods := make(map[string]*protogen.Method)
+
+	g.walkOperations(doc, func(_, _ string, op *openapi3.Operation, method *protogen.Method) {
+		other, ok := methods[op.OperationID]
+		if !ok {
+			methods[op.OperationID] = method
+			return
+		}
+
+		g.report(errorAt(locate(method.Desc), "operationId '%s' is already used by method '%s'", op.OperationID, other.Desc.FullName()))
+	})
+}
+
+// checkSchemaRefs reports each reference to a component schema that isn't in the document. It
+// returns whether all references resolve.
+func (g *Generator) checkSchemaRefs(doc *openapi3.T) bool {
+	ok := true
+
+	check := func(schemaRef *openapi3.SchemaRef, report func(err error)) {
+		walkSchemaRef(schemaRef, func(schemaRef *openapi3.SchemaRef) {
+			name := strings.TrimPrefix(schemaRef.Ref, schemaRefPrefix)
+			if name == schemaRef.Ref {
+				return
+			}
+
+			if _, exists := doc.Components.Schemas[name]; !exists {
+				ok = false
+				report(fmt.Errorf("schema '%s' is referenced but not generated", schemaRef.Ref))
+			}
+		})
+	}
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		properties := getComponentProperties(doc.Components.Schemas[name])
+
+		for _, propertyName := range sortedKeys(properties) {
+			check(properties[propertyName], func(err error) {
+				g.report(g.newPropertyDiagnostic(name, propertyName, "", err))
+			})
+		}
+	}
+
+	g.walkOperations(doc, func(_, _ string, op *openapi3.Operation, method *protogen.Method) {
+		walkOperationSchemas("", op, func(_ string, schemaRef *openapi3.SchemaRef) {
+			check(schemaRef, func(err error) {
+				g.report(locatedAt(method.Desc, err))
+			})
+		})
+	})
+
+	return ok
+}
+
+// stubRefs replaces the values of the schema and response references of the document with empty
+// ones and returns a function that restores them. The components they point to are validated on
+// their own or are in another file, so they aren't validated again for each reference.
+func (g *Generator) stubRefs(doc *openapi3.T) func() {
+	schemas := make(map[*openapi3.SchemaRef]*openapi3.Schema)
+	responses := make(map[*openapi3.ResponseRef]*openapi3.Response)
+
+	description := ""
+
+	for _, paths := range []openapi3.Paths{doc.Paths, g.webhooks} {
+		for _, pathItem := range paths {
+			for _, op := range pathItem.Operations() {
+				for _, responseRef := range op.Responses {
+					if _, ok := responses[responseRef]; ok || responseRef.Ref == "" {
+						continue
+					}
+
+					responses[responseRef] = responseRef.Value
+					responseRef.Value = &openapi3.Response{Description: &description}
+				}
+			}
+		}
+	}
+
+	walkDocumentSchemas(doc, g.webhooks, func(_ string, schemaRef *openapi3.SchemaRef) {
+		walkSchemaRef(schemaRef, func(schemaRef *openapi3.SchemaRef) {
+			if _, ok := schemas[schemaRef]; ok || schemaRef.Ref == "" {
+				return
+			}
+
+			schemas[schemaRef] = schemaRef.Value
+			schemaRef.Value = &openapi3.Schema{}
+		})
+	})
+
+	return func() {
+		for schemaRef, schema := range schemas {
+			schemaRef.Value = schema
+		}
+
+		for responseRef, response := range responses {
+			responseRef.Value = response
+		}
+	}
+}
+
+// validateComponentSchemas validates each component schema. The error of a schema is reported at
+// the first of its properties that's invalid on its own, or at its message if there's none.
+func (g *Generator) validateComponentSchemas(ctx context.Context, doc *openapi3.T) {
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		schemaRef := doc.Components.Schemas[name]
+
+		err := openapi3.ValidateIdentifier(name)
+		if err == nil {
+			err = schemaRef.Validate(ctx)
+		}
+
+		if err != nil {
+			g.report(g.newSchemaDiagnostic(ctx, name, schemaRef, err))
+		}
+	}
+}
+
+// newSchemaDiagnostic returns an error diagnostic for the component schema. Its properties are only
+// validated on their own to find the one the error comes from.
+func (g *Generator) newSchemaDiagnostic(ctx context.Context, name string, schemaRef *openapi3.SchemaRef, err error) *diagnostic {
+	properties := getComponentProperties(schemaRef)
+
+	for _, propertyName := range sortedKeys(properties) {
+		propertyErr := properties[propertyName].Validate(ctx)
+		if propertyErr == nil {
+			continue
+		}
+
+		// Schema errors name the keyword, which is the field option with the same name.
+		option := ""
+
+		var schemaErr *openapi3.SchemaError
+		if errors.As(propertyErr, &schemaErr) {
+			option = schemaErr.SchemaField
+		}
+
+		return g.newPropertyDiagnostic(name, propertyName, option, propertyErr)
+	}
+
+	message := g.schemaSources[name]
+	if message == nil {
+		return &diagnostic{message: fmt.Sprintf("schema '%s': %v", name, err)}
+	}
+
+	return &diagnostic{location: locate(message.Desc), message: err.Error()}
+}
+
+// validateOperations validates each operation of the paths and webhooks of the document. Errors are
+// reported at the method the operation was generated from.
+func (g *Generator) validateOperations(ctx context.Context, doc *openapi3.T) {
+	for _, paths := range []openapi3.Paths{doc.Paths, g.webhooks} {
+		for _, path := range sortedKeys(paths) {
+			operations := paths[path].Operations()
+
+			for _, methodName := range sortedKeys(operations) {
+				op := operations[methodName]
+
+				err := op.Validate(ctx)
+				if err == nil {
+					continue
+				}
+
+				location := g.locateOperation(op)
+				if location == "" {
+					err = fmt.Errorf("operation '%s %s': %w", strings.ToUpper(methodName), path, err)
+				}
+
+				g.report(&diagnostic{location: location, message: err.Error()})
+			}
+		}
+	}
+}
+
+// checkExamples warns about each example that doesn't match its schema. Examples of component
+// properties are located at their fields and the others at their messages or methods.
+func (g *Generator) checkExamples(doc *openapi3.T) {
+	warn := func(location string, err error) {
+		g.report(&diagnostic{location: location, message: fmt.Sprintf("invalid example: %v", err), warning: true})
+	}
+
+	check := func(schemaRef *openapi3.SchemaRef, report func(err error)) {
+		walkSchemaRef(schemaRef, func(schemaRef *openapi3.SchemaRef) {
+			schema := schemaRef.Value
+			if schemaRef.Ref != "" || schema == nil || schema.Example == nil {
+				return
+			}
+
+			err := schema.VisitJSON(schema.Example, openapi3.MultiErrors())
+			if err != nil {
+				report(err)
+			}
+		})
+	}
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		schemaRef := doc.Components.Schemas[name]
+
+		location := ""
+		if message := g.schemaSources[name]; message != nil {
+			location = locate(message.Desc)
+		}
+
+		if schema := schemaRef.Value; schemaRef.Ref == "" && schema != nil && schema.Example != nil {
+			err := schema.VisitJSON(schema.Example, openapi3.MultiErrors())
+			if err != nil {
+				warn(location, err)
+			}
+		}
+
+		properties := getComponentProperties(schemaRef)
+
+		for _, propertyName := range sortedKeys(properties) {
+			check(properties[propertyName], func(err error) {
+				d := g.newPropertyDiagnostic(name, propertyName, "", fmt.Errorf("invalid example: %w", err))
+				d.warning = true
+				g.report(d)
+			})
+		}
+	}
+
+	for _, paths := range []openapi3.Paths{doc.Paths, g.webhooks} {
+		for _, path := range sortedKeys(paths) {
+			for _, op := range paths[path].Operations() {
+				location := g.locateOperation(op)
+
+				for _, paramRef := range op.Parameters {
+					param := paramRef.Value
+					if param == nil || param.Example == nil || param.Schema == nil || param.Schema.Value == nil {
+						continue
+					}
+
+					err := param.Schema.Value.VisitJSON(param.Example, openapi3.MultiErrors())
+					if err != nil {
+						warn(location, fmt.Errorf("parameter '%s': %w", param.Name, err))
+					}
+				}
+
+				walkOperationSchemas("", op, func(_ string, schemaRef *openapi3.SchemaRef) {
+					check(schemaRef, func(err error) {
+						warn(location, err)
+					})
+				})
+			}
+		}
+	}
+}
+
+// validateDocumentRest validates the document without its component schemas and operations, which
+// are validated on their own. Webhooks are an extension to kin-openapi, so they're left out too.
+func validateDocumentRest(ctx context.Context, doc *openapi3.T) error {
+	rest := *doc
+
+	if doc.Components != nil {
+		components := *doc.Components
+		components.Schemas = nil
+		rest.Components = &components
+	}
+
+	// The title has always been optional here, so it's only a warning, which is reported when the
+	// document is built.
+	if doc.Info != nil && doc.Info.Title == "" {
+		info := *doc.Info
+		info.Title = "-"
+		rest.Info = &info
+	}
+
+	rest.Paths = make(openapi3.Paths, len(doc.Paths))
+	for path, pathItem := range doc.Paths {
+		rest.Paths[path] = &openapi3.PathItem{
+			Extensions:  pathItem.Extensions,
+			Ref:         pathItem.Ref,
+			Summary:     pathItem.Summary,
+			Description: pathItem.Description,
+			Servers:     pathItem.Servers,
+			Parameters:  pathItem.Parameters,
+		}
+	}
+
+	rest.Extensions = make(map[string]any, len(doc.Extensions))
+	for key, value := range doc.Extensions {
+		if key != "webhooks" {
+			rest.Extensions[key] = value
+		}
+	}
+
+	return rest.Validate(ctx)
+}
+
+// newPropertyDiagnostic returns an error diagnostic for a property of a component schema. It's
+// located at the option of the field the property was generated from if there's one.
+func (g *Generator) newPropertyDiagnostic(schemaName, propertyName, option string, err error) *diagnostic {
+	message := g.schemaSources[schemaName]
+	if message == nil {
+		return &diagnostic{message: fmt.Sprintf("property '%s' of schema '%s': %v", propertyName, schemaName, err)}
+	}
+
+	for _, field := range message.Fields {
+		if g.getFieldName(field) != propertyName {
+			continue
+		}
+
+		location := locate(field.Desc)
+		if option != "" {
+			location = locateOption(field.Desc, option)
+		}
+
+		return &diagnostic{location: location, message: err.Error()}
+	}
+
+	return &diagnostic{location: locate(message.Desc), message: fmt.Sprintf("property '%s': %v", propertyName, err)}
+}
+
+// validateOutput checks the node tree of the document as it's written, after the output format and
+// version and the overlays are applied. The rest of the document is validated before it's encoded,
+// so only the path parameters of the operations are checked against their paths again, since
+// overlays can change both.
+func (g *Generator) validateOutput(doc *openapi3.T, root *yaml.Node) error {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	g.checkPathParameters(doc, root)
+
+	return g.checkReported()
+}
+
+// checkPathParameters reports each operation of the written document whose path parameters don't
+// match the variables of its path. Operations are located at the method they were generated from
+// by their operation ID.
+func (g *Generator) checkPathParameters(doc *openapi3.T, root *yaml.Node) {
+	methods := make(map[string]*protogen.Method)
+	g.walkOperations(doc, func(_, _ string, op *openapi3.Operation, method *protogen.Method) {
+		methods[op.OperationID] = method
+	})
+
+	paths := getMappingValue(root, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path, pathItem := paths.Content[i].Value, paths.Content[i+1]
+
+		variables := make(map[string]bool)
+		for _, match := range pathVariableRegexp.FindAllStringSubmatch(path, -1) {
+			variables[match[1]] = true
+		}
+
+		for j := 0; j+1 < len(pathItem.Content); j += 2 {
+			methodName := pathItem.Content[j].Value
+			if !isOperationKey(methodName) {
+				continue
+			}
+
+			op := pathItem.Content[j+1]
+
+			location := ""
+			if operationID := getMappingValue(op, "operationId"); operationID != nil && methods[operationID.Value] != nil {
+				location = locate(methods[operationID.Value].Desc)
+			}
+
+			// Parameters of the operation override those of the path item.
+			params, ok := resolveParameters(root, getMappingValue(pathItem, "parameters"), getMappingValue(op, "parameters"))
+			if !ok {
+				// Parameters in other files can't be checked.
+				continue
+			}
+
+			operation := fmt.Sprintf("%s %s", strings.ToUpper(methodName), path)
+
+			for _, name := range sortedKeys(params) {
+				if !variables[name] {
+					g.report(errorAt(location, "path parameter '%s' of operation '%s' isn't in the path", name, operation))
+				} else if !params[name] {
+					g.report(errorAt(location, "path parameter '%s' of operation '%s' isn't required", name, operation))
+				}
+			}
+
+			for _, name := range sortedKeys(variables) {
+				if _, ok := params[name]; !ok {
+					g.report(errorAt(location, "path variable {%s} of operation '%s' has no parameter", name, operation))
+				}
+			}
+		}
+	}
+}
+
+// resolveParameters returns whether each path parameter of the lists of parameters is required by
+// name. References are resolved within the document and false is returned if any can't be.
+func resolveParameters(root *yaml.Node, lists ...*yaml.Node) (map[string]bool, bool) {
+	params := make(map[string]bool)
+
+	for _, list := range lists {
+		if list == nil {
+			continue
+		}
+
+		for _, param := range list.Content {
+			if ref := getMappingValue(param, "$ref"); ref != nil {
+				param = resolvePointer(root, ref.Value)
+				if param == nil {
+					return nil, false
+				}
+			}
+
+			in := getMappingValue(param, "in")
+			name := getMappingValue(param, "name")
+			if in == nil || name == nil || in.Value != openapi3.ParameterInPath {
+				continue
+			}
+
+			required := getMappingValue(param, "required")
+			params[name.Value] = required != nil && required.Value == "true"
+		}
+	}
+
+	return params, true
+}
+
+// resolvePointer returns the node of the document the local reference points to or nil if it
+// doesn't point to one.
+func resolvePointer(root *yaml.Node, ref string) *yaml.Node {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil
+	}
+
+	node := root
+	for _, key := range strings.Split(pointer, "/") {
+		key = strings.ReplaceAll(strings.ReplaceAll(key, "~1", "/"), "~0", "~")
+
+		node = getMappingValue(node, key)
+		if node == nil {
+			return nil
+		}
+	}
+
+	return node
+}
+
+// walkOperations calls fn with each operation of the paths and webhooks of the document in order of
+// their paths along with the method it was generated from.
+func (g *Generator) walkOperations(doc *openapi3.T, fn func(path, methodName string, op *openapi3.Operation, method *protogen.Method)) {
+	for _, paths := range []openapi3.Paths{doc.Paths, g.webhooks} {
+		for _, path := range sortedKeys(paths) {
+			operations := paths[path].Operations()
+
+			for _, methodName := range sortedKeys(operations) {
+				op := operations[methodName]
+
+				if method, ok := g.operationSources[op]; ok {
+					fn(path, methodName, op, method)
+				}
+			}
+		}
+	}
+}
+
+// getComponentProperties returns the properties of the component schema by name. Properties of
+// oneofs are in the subschemas.
+func getComponentProperties(schemaRef *openapi3.SchemaRef) openapi3.Schemas {
+	properties := make(openapi3.Schemas)
+
+	if schemaRef.Value == nil {
+		return properties
+	}
+
+	schemas := openapi3.SchemaRefs{schemaRef}
+	schemas = append(schemas, schemaRef.Value.OneOf...)
+	schemas = append(schemas, schemaRef.Value.AnyOf...)
+	schemas = append(schemas, schemaRef.Value.AllOf...)
+
+	for _, schema := range schemas {
+		if schema.Ref != "" || schema.Value == nil {
+			continue
+		}
+
+		for name, propertyRef := range schema.Value.Properties {
+			properties[name] = propertyRef
+		}
+	}
+
+	return properties
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
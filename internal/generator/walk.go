@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// walkDocumentSchemas calls fn with each top level schema of the document and where it's located.
+// These are the component schemas and the schemas of parameters, request bodies and responses of
+// components, paths and webhooks. Subschemas are left to fn.
+func walkDocumentSchemas(doc *openapi3.T, webhooks openapi3.Paths, fn func(location string, schemaRef *openapi3.SchemaRef)) {
+	for name, schemaRef := range doc.Components.Schemas {
+		fn(fmt.Sprintf("schema '%s'", name), schemaRef)
+	}
+
+	walkPathSchemas(doc, webhooks, fn)
+}
+
+// walkPathSchemas calls fn with each top level schema of the document other than the component
+// schemas and where it's located. These are the schemas of parameters, request bodies and responses
+// of components, paths and webhooks. Subschemas are left to fn.
+func walkPathSchemas(doc *openapi3.T, webhooks openapi3.Paths, fn func(location string, schemaRef *openapi3.SchemaRef)) {
+	for name, responseRef := range doc.Components.Responses {
+		if responseRef.Value != nil {
+			walkContentSchemas(fmt.Sprintf("response '%s'", name), responseRef.Value.Content, fn)
+		}
+	}
+
+	for name, requestBodyRef := range doc.Components.RequestBodies {
+		if requestBodyRef.Value != nil {
+			walkContentSchemas(fmt.Sprintf("request body '%s'", name), requestBodyRef.Value.Content, fn)
+		}
+	}
+
+	for _, paths := range []openapi3.Paths{doc.Paths, webhooks} {
+		for path, pathItem := range paths {
+			walkParameterSchemas(fmt.Sprintf("path '%s'", path), pathItem.Parameters, fn)
+
+			for _, op := range pathItem.Operations() {
+				walkOperationSchemas(fmt.Sprintf("operation '%s'", op.OperationID), op, fn)
+			}
+		}
+	}
+}
+
+// walkOperationSchemas calls fn with the schemas of the parameters, request body and responses of
+// the operation.
+func walkOperationSchemas(location string, op *openapi3.Operation, fn func(location string, schemaRef *openapi3.SchemaRef)) {
+	walkParameterSchemas(location, op.Parameters, fn)
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		walkContentSchemas(location, op.RequestBody.Value.Content, fn)
+	}
+
+	for _, responseRef := range op.Responses {
+		if responseRef.Value != nil {
+			walkContentSchemas(location, responseRef.Value.Content, fn)
+		}
+	}
+}
+
+// walkParameterSchemas calls fn with the schema of each parameter.
+func walkParameterSchemas(location string, params openapi3.Parameters, fn func(location string, schemaRef *openapi3.SchemaRef)) {
+	for _, paramRef := range params {
+		if paramRef.Value != nil {
+			fn(fmt.Sprintf("%s parameter '%s'", location, paramRef.Value.Name), paramRef.Value.Schema)
+		}
+	}
+}
+
+// walkContentSchemas calls fn with the schema of each media type of the content.
+func walkContentSchemas(location string, content openapi3.Content, fn func(location string, schemaRef *openapi3.SchemaRef)) {
+	for _, mediaType := range content {
+		fn(location, mediaType.Schema)
+	}
+}
+
+// walkSchemaRef calls fn with the schema reference and each of its subschemas. Referenced schemas
+// aren't followed.
+func walkSchemaRef(schemaRef *openapi3.SchemaRef, fn func(schemaRef *openapi3.SchemaRef)) {
+	if schemaRef == nil {
+		return
+	}
+
+	fn(schemaRef)
+
+	if schemaRef.Ref != "" || schemaRef.Value == nil {
+		return
+	}
+
+	schema := schemaRef.Value
+
+	for _, propertyRef := range schema.Properties {
+		walkSchemaRef(propertyRef, fn)
+	}
+
+	for _, schemaRefs := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf, schema.AllOf} {
+		for _, subSchemaRef := range schemaRefs {
+			walkSchemaRef(subSchemaRef, fn)
+		}
+	}
+
+	walkSchemaRef(schema.Items, fn)
+	walkSchemaRef(schema.Not, fn)
+	walkSchemaRef(schema.AdditionalProperties.Schema, fn)
+}
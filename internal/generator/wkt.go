@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const emptyFullName protoreflect.FullName = "google.protobuf.Empty"
+
+var (
+	// wellKnownSchemas holds functions that return the protojson representation of the
+	// google.protobuf well-known types. These are used instead of building out the messages.
+	wellKnownSchemas = map[protoreflect.FullName]func() *openapi3.Schema{
+		"google.protobuf.Timestamp": func() *openapi3.Schema {
+			return &openapi3.Schema{
+				Type:   openapi3.TypeString,
+				Format: "date-time",
+			}
+		},
+		"google.protobuf.Duration": func() *openapi3.Schema {
+			return &openapi3.Schema{
+				Type:    openapi3.TypeString,
+				Pattern: `^-?[0-9]+(\.[0-9]{1,9})?s$`,
+				Example: "1.5s",
+			}
+		},
+		"google.protobuf.Struct": func() *openapi3.Schema {
+			return &openapi3.Schema{
+				Type:                 openapi3.TypeObject,
+				AdditionalProperties: openapi3.AdditionalProperties{Has: boolPtr(true)},
+			}
+		},
+		"google.protobuf.Value": func() *openapi3.Schema {
+			// Any JSON value including null.
+			return &openapi3.Schema{
+				Nullable: true,
+			}
+		},
+		"google.protobuf.ListValue": func() *openapi3.Schema {
+			return &openapi3.Schema{
+				Type: openapi3.TypeArray,
+				Items: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Nullable: true,
+					},
+				},
+			}
+		},
+		"google.protobuf.FieldMask": func() *openapi3.Schema {
+			// Comma-separated list of field paths.
+			return &openapi3.Schema{
+				Type: openapi3.TypeString,
+			}
+		},
+		emptyFullName: func() *openapi3.Schema {
+			return &openapi3.Schema{
+				Type:       openapi3.TypeObject,
+				Properties: make(openapi3.Schemas),
+			}
+		},
+		"google.protobuf.Any": func() *openapi3.Schema {
+			return &openapi3.Schema{
+				Type: openapi3.TypeObject,
+				Properties: openapi3.Schemas{
+					"@type": &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type: openapi3.TypeString,
+						},
+					},
+				},
+				AdditionalProperties: openapi3.AdditionalProperties{Has: boolPtr(true)},
+			}
+		},
+		"google.protobuf.DoubleValue": newWrapperSchema(openapi3.TypeNumber, "double"),
+		"google.protobuf.FloatValue":  newWrapperSchema(openapi3.TypeNumber, "float"),
+		"google.protobuf.Int64Value":  newWrapperSchema(openapi3.TypeString, "int64"),
+		"google.protobuf.UInt64Value": newWrapperSchema(openapi3.TypeString, "uint64"),
+		"google.protobuf.Int32Value":  newWrapperSchema(openapi3.TypeInteger, "int32"),
+		"google.protobuf.UInt32Value": newWrapperSchema(openapi3.TypeInteger, "uint32"),
+		"google.protobuf.BoolValue":   newWrapperSchema(openapi3.TypeBoolean, ""),
+		"google.protobuf.StringValue": newWrapperSchema(openapi3.TypeString, ""),
+		"google.protobuf.BytesValue":  newWrapperSchema(openapi3.TypeString, "byte"),
+	}
+)
+
+// newWrapperSchema returns a function that creates a nullable primitive schema for the wrapper
+// types.
+func newWrapperSchema(apiType, format string) func() *openapi3.Schema {
+	return func() *openapi3.Schema {
+		return &openapi3.Schema{
+			Type:     apiType,
+			Format:   format,
+			Nullable: true,
+		}
+	}
+}
+
+// newWellKnownSchema returns a new schema for the well-known type or nil if the name isn't one.
+func newWellKnownSchema(name protoreflect.FullName) *openapi3.Schema {
+	fn, ok := wellKnownSchemas[name]
+	if !ok {
+		return nil
+	}
+
+	return fn()
+}
+
+// isWellKnownType returns whether the message is a well-known type with a defined schema.
+func isWellKnownType(message *protogen.Message) bool {
+	_, ok := wellKnownSchemas[message.Desc.FullName()]
+	return ok
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
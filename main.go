@@ -2,28 +2,31 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/technicallyjosh/protoc-gen-openapi/internal/descriptorset"
+	"github.com/technicallyjosh/protoc-gen-openapi/internal/diff"
 	"github.com/technicallyjosh/protoc-gen-openapi/internal/generator"
 	"google.golang.org/protobuf/compiler/protogen"
 )
 
 func main() {
-	var flags flag.FlagSet
-
-	conf := generator.Config{
-		ContentType:     flags.String("content_type", "application/json", "Default content-type for all paths."),
-		DefaultResponse: flags.String("default_response", "", "Default response message to use for API responses not defined."),
-		Description:     flags.String("description", "", "Description of the API."),
-		Filename:        flags.String("filename", "openapi", "Name of the file generated without the extension."),
-		Host:            flags.String("host", "", "Host to be used for all routes."),
-		Ignore:          flags.String("ignore", "", "Packages to ignore."),
-		Include:         flags.String("include", "", "Packages to include. Ignore overrides this."),
-		JSONOutput:      flags.Bool("json_out", false, "Generate a JSON file instead of YAML."),
-		Title:           flags.String("title", "", "Title of the API"),
-		UseJSONNames:    flags.Bool("json_names", false, "Use JSON names instead of the proto names of fields."),
-		Version:         flags.String("version", "0.0.1", "Version of the API."),
+	// protoc runs plugins without arguments, so any are a subcommand.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "diff":
+			os.Exit(runDiff(os.Args[2:]))
+		case "generate":
+			os.Exit(runGenerate(os.Args[2:]))
+		}
 	}
 
+	var flags flag.FlagSet
+	conf := newConfig(&flags)
+
 	opts := protogen.Options{
 		ParamFunc: flags.Set,
 	}
@@ -32,3 +35,156 @@ func main() {
 		return generator.New(plugin, conf).Run()
 	})
 }
+
+// newConfig returns the config of the plugin options defined on the flags.
+func newConfig(flags *flag.FlagSet) generator.Config {
+	conf := generator.Config{
+		BaseFile:          flags.String("base_file", "", "Path of an OpenAPI document to merge the generated document into."),
+		ComponentStrategy: flags.String("component_strategy", "suffix", "Strategy for which messages are component schemas: suffix, rpc or all."),
+		ContentType:       flags.String("content_type", "application/json", "Default content-type for all paths."),
+		DefaultResponse:   flags.String("default_response", "", "Default response message to use for API responses not defined."),
+		Description:       flags.String("description", "", "Description of the API."),
+		Filename:          flags.String("filename", "openapi", "Name of the file generated without the extension."),
+		Host:              flags.String("host", "", "Host to be used for all routes."),
+		Ignore:            flags.String("ignore", "", "Packages to ignore."),
+		Include:           flags.String("include", "", "Packages to include. Ignore overrides this."),
+		InputQuery:        flags.Bool("input_query", false, "Expand the input message fields into query parameters for GET and DELETE methods."),
+		JSONOutput:        flags.Bool("json_out", false, "Generate a JSON file instead of YAML."),
+		OpenAPIVersion:    flags.String("openapi_version", "3.0", "Version of the OpenAPI document: 3.0 or 3.1."),
+		OutputFormat:      flags.String("output_format", "openapi", "Format of the generated file: openapi or swagger2."),
+		OutputMode:        flags.String("output_mode", "single", "Documents to generate: single, per_package, per_service or per_file."),
+		Overlays:          new(generator.StringList),
+		PathOrder:         flags.String("path_order", "declaration", "Order of the paths: declaration or sorted."),
+		Routing:           flags.String("routing", "", "Route methods without a path to their twirp or connect path."),
+		SharedComponents:  flags.Bool("shared_components", false, "Reference component schemas from a shared file instead of duplicating them when output_mode isn't single."),
+		Strict:            flags.Bool("strict", false, "Fail the generation on warnings."),
+		Title:             flags.String("title", "", "Title of the API"),
+		UseEnumNumbers:    flags.Bool("enum_numbers", false, "Use enum numbers instead of names for enum values."),
+		UseJSONNames:      flags.Bool("json_names", false, "Use JSON names instead of the proto names of fields."),
+		Version:           flags.String("version", "0.0.1", "Version of the API."),
+	}
+
+	flags.Var(conf.Overlays, "overlay", "Path of an OpenAPI Overlay to apply to the generated documents. Can be set multiple times.")
+
+	return conf
+}
+
+// runGenerate generates the documents of a descriptor set like protoc would and writes them to the
+// output directory. It returns 1 if the generation fails and 2 if the arguments are invalid.
+func runGenerate(args []string) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	descriptorSet := flags.String("descriptor_set", "", "Path of a serialized FileDescriptorSet or buf image including imports.")
+	out := flags.String("out", ".", "Directory to write the generated files to.")
+
+	var opts, files generator.StringList
+	flags.Var(&opts, "opt", "Plugin option as name=value like --openapi_opt. Can be set multiple times.")
+	flags.Var(&files, "file", "Proto file of the set to generate. Can be set multiple times. Defaults to the files that aren't imported.")
+
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: protoc-gen-openapi generate --descriptor_set=<file> [flags]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *descriptorSet == "" || flags.NArg() != 0 {
+		flags.Usage()
+		return 2
+	}
+
+	req, err := descriptorset.NewRequest(*descriptorSet, files, strings.Join(opts, ","))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	var pluginFlags flag.FlagSet
+	conf := newConfig(&pluginFlags)
+
+	plugin, err := protogen.Options{ParamFunc: pluginFlags.Set}.New(req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	err = generator.New(plugin, conf).Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "protoc-gen-openapi: %v\n", err)
+		return 1
+	}
+
+	for _, file := range plugin.Response().File {
+		filename := filepath.Join(*out, file.GetName())
+
+		err = os.MkdirAll(filepath.Dir(filename), 0o755)
+		if err == nil {
+			err = os.WriteFile(filename, []byte(file.GetContent()), 0o644)
+		}
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// runDiff compares two generated documents and returns 1 if there are breaking changes and 2 if
+// they can't be compared.
+func runDiff(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	breakingOnly := flags.Bool("breaking_only", false, "Only print breaking changes.")
+
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: protoc-gen-openapi diff [flags] <base> <revision>")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	base, err := diff.Load(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	revision, err := diff.Load(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	breaking := 0
+
+	for _, change := range diff.Compare(base, revision) {
+		if change.Breaking {
+			breaking++
+		} else if *breakingOnly {
+			continue
+		}
+
+		fmt.Println(change)
+	}
+
+	if breaking == 1 {
+		fmt.Fprintln(os.Stderr, "1 breaking change")
+		return 1
+	}
+
+	if breaking > 0 {
+		fmt.Fprintf(os.Stderr, "%d breaking changes\n", breaking)
+		return 1
+	}
+
+	return 0
+}
@@ -1,13 +1,29 @@
 package main_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	jd "github.com/josephburnett/jd/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	oapiv1 "github.com/technicallyjosh/protoc-gen-openapi/api/oapi/v1"
+	"github.com/technicallyjosh/protoc-gen-openapi/internal/generator"
+	"github.com/technicallyjosh/protoc-gen-openapi/openapi"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
 	"gopkg.in/yaml.v3"
 )
 
@@ -24,6 +40,8 @@ type TestOptions struct {
 type TestSuite struct {
 	suite.Suite
 	rawDoc  []byte
+	rawDocs map[string][]byte
+	errOut  string
 	options TestOptions
 }
 
@@ -78,6 +96,13 @@ func (s *TestSuite) SetupSuite() {
 
 func (s *TestSuite) BeforeTest(suite, name string) {
 	var filename string
+	var opts []string
+	// outputs are the files generated instead of openapi.yaml.
+	var outputs []string
+	// fails is whether protoc is expected to fail.
+	var fails bool
+	// otherFiles are generated along with the file.
+	var otherFiles []string
 
 	switch name {
 	case "TestBasic":
@@ -88,8 +113,95 @@ func (s *TestSuite) BeforeTest(suite, name string) {
 		filename = "service_test.proto"
 	case "TestMethod":
 		filename = "method_test.proto"
+	case "TestServiceParameters":
+		filename = "service_parameters_test.proto"
 	case "TestField":
 		filename = "field_test.proto"
+	case "TestEnum":
+		filename = "enum_test.proto"
+	case "TestEnumNumbers":
+		filename = "enum_test.proto"
+		opts = append(opts, "enum_numbers=true")
+	case "TestMap":
+		filename = "map_test.proto"
+	case "TestWellKnownTypes":
+		filename = "wkt_test.proto"
+	case "TestOneof":
+		filename = "oneof_test.proto"
+	case "TestRecursion":
+		filename = "recursion_test.proto"
+	case "TestComponent":
+		filename = "component_test.proto"
+	case "TestComponentRPC":
+		filename = "component_test.proto"
+		opts = append(opts, "component_strategy=rpc")
+	case "TestQuery":
+		filename = "query_test.proto"
+	case "TestBinding":
+		filename = "binding_test.proto"
+	case "TestHTTP":
+		filename = "http_test.proto"
+	case "TestRoutingTwirp":
+		filename = "routing_test.proto"
+		opts = append(opts, "routing=twirp", "default_response=")
+	case "TestRoutingConnect":
+		filename = "routing_test.proto"
+		opts = append(opts, "routing=connect", "default_response=")
+	case "TestOpenAPI31":
+		filename = "openapi31_test.proto"
+		opts = append(opts, "openapi_version=3.1")
+	case "TestOpenAPI31Error":
+		filename = "openapi31_error_test.proto"
+		opts = append(opts, "openapi_version=3.1")
+		fails = true
+	case "TestSwagger2":
+		filename = "swagger2_test.proto"
+		opts = append(opts, "output_format=swagger2")
+	case "TestBaseFile":
+		filename = "base_test.proto"
+		opts = append(opts, "base_file=test/base_test_base.yaml")
+	case "TestOverlay":
+		filename = "overlay_test.proto"
+		opts = append(opts, "overlay=test/overlay_test_overlay.yaml")
+	case "TestOverlayInvalid":
+		filename = "overlay_test.proto"
+		opts = append(opts, "overlay=test/overlay_test_invalid_overlay.yaml")
+		fails = true
+	case "TestValidate":
+		filename = "validate_test.proto"
+		fails = true
+	case "TestDiagnostic":
+		filename = "diagnostic_test.proto"
+		fails = true
+	case "TestStrict":
+		filename = "basic_test.proto"
+		opts = append(opts, "title=", "strict=true")
+		fails = true
+	case "TestSecurity":
+		filename = "security_test.proto"
+	case "TestSecurityImport":
+		filename = "security_import_test.proto"
+	case "TestSecurityMode":
+		filename = "security_mode_test.proto"
+	case "TestSecurityError":
+		filename = "security_error_test.proto"
+		otherFiles = append(otherFiles, "test/security_schemes.proto")
+		fails = true
+	case "TestOrder":
+		filename = "field_test.proto"
+	case "TestPathOrder":
+		filename = "http_test.proto"
+	case "TestPathOrderSorted":
+		filename = "http_test.proto"
+		opts = append(opts, "path_order=sorted")
+	case "TestOutputPerService":
+		filename = "output_test.proto"
+		opts = append(opts, "output_mode=per_service")
+		outputs = []string{"test.api.TestUserService.openapi.yaml", "test.api.TestPetService.openapi.yaml"}
+	case "TestOutputSharedComponents":
+		filename = "output_test.proto"
+		opts = append(opts, "output_mode=per_service", "shared_components=true")
+		outputs = []string{"test.api.TestUserService.openapi.yaml", "test.api.TestPetService.openapi.yaml", "openapi.components.yaml"}
 	default:
 		s.FailNow("invalid test name")
 	}
@@ -99,22 +211,58 @@ func (s *TestSuite) BeforeTest(suite, name string) {
 		s.FailNow(err.Error())
 	}
 
-	out, err := exec.Command("protoc",
+	outDir := "test"
+	if len(outputs) > 0 {
+		outDir = s.T().TempDir()
+	}
+
+	args := []string{
 		"-I=api",
 		"-I=test",
-		"--openapi_out=test",
-		"--openapi_opt=version="+s.options.version,
-		"--openapi_opt=title="+s.options.title,
-		"--openapi_opt=description="+s.options.description,
-		"--openapi_opt=default_response="+s.options.defaultResponse,
-		"--openapi_opt=include="+s.options.include,
-		"--openapi_opt=ignore="+s.options.ignore,
-		"test/"+filename,
-	).CombinedOutput()
+		"--openapi_out=" + outDir,
+		"--openapi_opt=version=" + s.options.version,
+		"--openapi_opt=title=" + s.options.title,
+		"--openapi_opt=description=" + s.options.description,
+		"--openapi_opt=default_response=" + s.options.defaultResponse,
+		"--openapi_opt=include=" + s.options.include,
+		"--openapi_opt=ignore=" + s.options.ignore,
+	}
+
+	for _, opt := range opts {
+		args = append(args, "--openapi_opt="+opt)
+	}
+
+	args = append(args, "test/"+filename)
+	args = append(args, otherFiles...)
+
+	out, err := exec.Command("protoc", args...).CombinedOutput()
+	s.errOut = string(out)
+
+	if fails {
+		if err == nil {
+			s.FailNow("expected protoc to fail")
+		}
+
+		return
+	}
+
 	if err != nil {
 		s.FailNow(string(out))
 	}
 
+	if len(outputs) > 0 {
+		s.rawDocs = make(map[string][]byte)
+
+		for _, output := range outputs {
+			s.rawDocs[output], err = os.ReadFile(filepath.Join(outDir, output))
+			if err != nil {
+				s.FailNow(err.Error())
+			}
+		}
+
+		return
+	}
+
 	s.rawDoc, err = os.ReadFile("test/openapi.yaml")
 	if err != nil {
 		s.FailNow(err.Error())
@@ -137,14 +285,485 @@ func (s *TestSuite) TestMethod() {
 	s.YAMLEqual(readFile("method_test_openapi.yaml"), string(s.rawDoc))
 }
 
+func (s *TestSuite) TestServiceParameters() {
+	s.YAMLEqual(readFile("service_parameters_test_openapi.yaml"), string(s.rawDoc))
+}
+
 func (s *TestSuite) TestField() {
 	s.YAMLEqual(readFile("field_test_openapi.yaml"), string(s.rawDoc))
 }
 
+func (s *TestSuite) TestEnum() {
+	s.YAMLEqual(readFile("enum_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestEnumNumbers() {
+	s.YAMLEqual(readFile("enum_numbers_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestMap() {
+	s.YAMLEqual(readFile("map_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestWellKnownTypes() {
+	s.YAMLEqual(readFile("wkt_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestOneof() {
+	s.YAMLEqual(readFile("oneof_test_openapi.yaml"), string(s.rawDoc))
+	s.Contains(s.errOut, "oneof_test.proto:38:5: warning: discriminator 'type' of oneof 'test.api.Pet.kind' is left out since its alternatives aren't component schemas")
+}
+
+func (s *TestSuite) TestRecursion() {
+	s.YAMLEqual(readFile("recursion_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestComponent() {
+	s.YAMLEqual(readFile("component_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestComponentRPC() {
+	s.YAMLEqual(readFile("component_rpc_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestQuery() {
+	s.YAMLEqual(readFile("query_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestBinding() {
+	s.YAMLEqual(readFile("binding_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestHTTP() {
+	s.YAMLEqual(readFile("http_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestRoutingTwirp() {
+	s.YAMLEqual(readFile("routing_twirp_test_openapi.yaml"), string(s.rawDoc))
+	s.Contains(s.errOut, "routing_test.proto:22:3: warning: streaming method 'test.api.TestService.TestWatchUsers' can't be routed with twirp routing, so it's skipped")
+}
+
+func (s *TestSuite) TestRoutingConnect() {
+	s.YAMLEqual(readFile("routing_connect_test_openapi.yaml"), string(s.rawDoc))
+	s.Contains(s.errOut, "routing_test.proto:22:3: warning: streaming method 'test.api.TestService.TestWatchUsers' can't be routed with connect routing, so it's skipped")
+}
+
+func (s *TestSuite) TestOpenAPI31() {
+	s.YAMLEqual(readFile("openapi31_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestOpenAPI31Error() {
+	s.Contains(s.errOut, "openapi31_error_test.proto:24:21: exclusive_min of field 'test.api.TestItem.price' needs min with openapi_version 3.1")
+	s.Contains(s.errOut, "openapi31_error_test.proto:31:22: exclusive_max of field 'test.api.TestShipment.weight' needs max with openapi_version 3.1")
+	s.Contains(s.errOut, "2 errors reported")
+}
+
+func (s *TestSuite) TestSwagger2() {
+	s.YAMLEqual(readFile("swagger2_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestBaseFile() {
+	s.YAMLEqual(readFile("base_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestOverlay() {
+	s.YAMLEqual(readFile("overlay_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestOverlayInvalid() {
+	s.Contains(s.errOut, "overlay_test.proto:19:3: path variable {id} of operation 'DELETE /v1/users/{id}' has no parameter")
+}
+
+func (s *TestSuite) TestValidate() {
+	s.Contains(s.errOut, "validate_test.proto:16:3: operationId 'Test_User_Get' is already used by method 'test.api.Test_User.Get'")
+	s.Contains(s.errOut, "validate_test.proto:30:20: error parsing regexp")
+	// The response bodies are built inline, so they're invalid in each operation too.
+	s.Contains(s.errOut, "validate_test.proto:16:3: error parsing regexp")
+	s.Contains(s.errOut, "validate_test.proto:22:3: error parsing regexp")
+	s.Contains(s.errOut, "4 errors reported")
+}
+
+func (s *TestSuite) TestDiagnostic() {
+	s.Contains(s.errOut, "diagnostic_test.proto:18:5: parameter {id} is missing from path /v1/users")
+	s.Contains(s.errOut, "diagnostic_test.proto:25:5: schema '#/components/schemas/test.api.MissingError' for method 'test.api.TestService.TestDeleteUser' default response not found")
+	s.Contains(s.errOut, "diagnostic_test.proto:31:3: path variable 'pet' must be bound to a scalar field")
+	s.Contains(s.errOut, "diagnostic_test.proto:56:5: discriminator 'type' isn't a field of message 'test.api.TestPet'")
+	s.Contains(s.errOut, "diagnostic_test.proto:70:3: schema name 'Owner' of message 'test.api.TestOrgOwner' is already used by message 'test.api.TestOwner'")
+	s.Contains(s.errOut, "5 errors reported")
+}
+
+func (s *TestSuite) TestStrict() {
+	s.Contains(s.errOut, "warning: title isn't set")
+	s.Contains(s.errOut, "strict is set and warnings were reported: 1")
+}
+
+func (s *TestSuite) TestSecurity() {
+	s.YAMLEqual(readFile("security_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestSecurityError() {
+	s.Contains(s.errOut, "security_error_test.proto:10:1: security scheme 'oauth' is invalid")
+	s.Contains(s.errOut, "field 'tokenUrl' is empty or missing")
+	s.Contains(s.errOut, "security_error_test.proto:28:5: scope 'users:delete' isn't declared by security scheme 'oauth'")
+	s.Contains(s.errOut, "security_error_test.proto:38:5: security scheme 'oauht' isn't declared")
+	s.Contains(s.errOut, "security_error_test.proto:45:5: security scheme 'api_key' is declared in 'security_schemes.proto', which 'security_error_test.proto' doesn't import")
+	s.Contains(s.errOut, "security_error_test.proto:52:5: security can't be set with security_mode SECURITY_MODE_INHERIT")
+	s.Contains(s.errOut, "5 errors reported")
+}
+
+func (s *TestSuite) TestSecurityMode() {
+	s.YAMLEqual(readFile("security_mode_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestSecurityImport() {
+	s.YAMLEqual(readFile("security_import_test_openapi.yaml"), string(s.rawDoc))
+}
+
+func (s *TestSuite) TestOutputPerService() {
+	s.YAMLEqual(readFile("output_user_test_openapi.yaml"), string(s.rawDocs["test.api.TestUserService.openapi.yaml"]))
+	s.YAMLEqual(readFile("output_pet_test_openapi.yaml"), string(s.rawDocs["test.api.TestPetService.openapi.yaml"]))
+}
+
+func (s *TestSuite) TestOutputSharedComponents() {
+	s.YAMLEqual(readFile("output_shared_user_test_openapi.yaml"), string(s.rawDocs["test.api.TestUserService.openapi.yaml"]))
+	s.YAMLEqual(readFile("output_shared_pet_test_openapi.yaml"), string(s.rawDocs["test.api.TestPetService.openapi.yaml"]))
+	s.YAMLEqual(readFile("output_shared_components_test_openapi.yaml"), string(s.rawDocs["openapi.components.yaml"]))
+}
+
+func (s *TestSuite) TestOrder() {
+	s.Equal([]string{"openapi", "info", "servers", "tags", "x-tagGroups", "paths", "components"}, s.getKeys())
+	s.Equal([]string{"title", "description", "version"}, s.getKeys("info"))
+	s.Equal([]string{"/v1/TestFieldTypes", "/v1/TestFieldExamples"}, s.getKeys("paths"))
+	s.Equal([]string{"tags", "operationId", "requestBody", "responses", "servers"}, s.getKeys("paths", "/v1/TestFieldTypes", "post"))
+	s.Equal(
+		[]string{"string", "bool", "int32", "int64", "uint32", "uint64", "repeated_string", "repeated_message", "repeated_request", "message_at"},
+		s.getKeys("paths", "/v1/TestFieldTypes", "post", "requestBody", "content", "application/json", "schema", "properties"),
+	)
+}
+
+func (s *TestSuite) TestPathOrder() {
+	s.Equal([]string{"/v1/{name}", "/v1/libraries/{library}/{name}", "/v1/shelves", "/v1/shelves/{shelf.id}", "/v1/shelves/{shelf.id}:rename", "/v1/shelves/{name}/name"}, s.getKeys("paths"))
+	s.Equal([]string{"get", "delete"}, s.getKeys("paths", "/v1/{name}"))
+}
+
+func (s *TestSuite) TestPathOrderSorted() {
+	s.Equal([]string{"/v1/libraries/{library}/{name}", "/v1/shelves", "/v1/shelves/{name}/name", "/v1/shelves/{shelf.id}", "/v1/shelves/{shelf.id}:rename", "/v1/{name}"}, s.getKeys("paths"))
+}
+
+// getKeys returns the keys of the object at the path of keys in the generated document in order.
+func (s *TestSuite) getKeys(path ...string) []string {
+	var node yaml.Node
+	s.Require().NoError(yaml.Unmarshal(s.rawDoc, &node))
+
+	current := node.Content[0]
+
+outer:
+	for _, key := range path {
+		for i := 0; i+1 < len(current.Content); i += 2 {
+			if current.Content[i].Value == key {
+				current = current.Content[i+1]
+				continue outer
+			}
+		}
+
+		s.FailNow(fmt.Sprintf("key '%s' not found", key))
+	}
+
+	keys := make([]string, 0, len(current.Content)/2)
+	for i := 0; i+1 < len(current.Content); i += 2 {
+		keys = append(keys, current.Content[i].Value)
+	}
+
+	return keys
+}
+
 func TestSuites(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
 
+func TestDiff(t *testing.T) {
+	out, err := exec.Command("protoc-gen-openapi", "diff", "test/diff_base.yaml", "test/diff_revision.yaml").CombinedOutput()
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+
+	assert.Contains(t, string(out), "breaking: /v1/users/{id}: path removed")
+	assert.Contains(t, string(out), "breaking: GET /v1/users query parameter 'page_size': type changed from 'integer' to 'string'")
+	assert.Contains(t, string(out), "breaking: POST /v1/users request body.name: property is now required")
+	assert.Contains(t, string(out), "breaking: POST /v1/users request body.role: enum values removed: GUEST")
+	assert.Contains(t, string(out), "non-breaking: GET /v1/users response 200 body.users[].role: enum values removed: GUEST")
+	assert.Contains(t, string(out), "non-breaking: /v1/pets: path added")
+	assert.Contains(t, strings.Split(string(out), "\n"), "4 breaking changes")
+
+	out, err = exec.Command("protoc-gen-openapi", "diff", "test/diff_base.yaml", "test/diff_base.yaml").CombinedOutput()
+	require.NoError(t, err, string(out))
+	assert.Empty(t, string(out))
+}
+
+func TestDiffOpenAPI31(t *testing.T) {
+	out, err := exec.Command("protoc-gen-openapi", "diff", "test/diff_base_31.yaml", "test/diff_revision_31.yaml").CombinedOutput()
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+
+	assert.Contains(t, string(out), "breaking: POST /v1/users request body.name: no longer nullable")
+	assert.Contains(t, string(out), "non-breaking: POST /v1/users response 200 body.name: no longer nullable")
+	assert.Contains(t, strings.Split(string(out), "\n"), "1 breaking change")
+
+	out, err = exec.Command("protoc-gen-openapi", "diff", "test/diff_base_31.yaml", "test/diff_base_31.yaml").CombinedOutput()
+	require.NoError(t, err, string(out))
+	assert.Empty(t, string(out))
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	descriptorSet := filepath.Join(dir, "method_test.binpb")
+
+	out, err := exec.Command("protoc", "-I=api", "-I=test", "--include_imports", "--include_source_info", "--descriptor_set_out="+descriptorSet, "test/method_test.proto").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	out, err = exec.Command(
+		"protoc-gen-openapi", "generate",
+		"--descriptor_set="+descriptorSet,
+		"--out="+dir,
+		"--opt=version=1.1.0",
+		"--opt=title=test title",
+		"--opt=description=test description",
+		"--opt=default_response=test.api.Error",
+	).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	doc, err := os.ReadFile(filepath.Join(dir, "openapi.yaml"))
+	require.NoError(t, err)
+
+	s := new(TestSuite)
+	s.SetT(t)
+	s.YAMLEqual(readFile("method_test_openapi.yaml"), doc)
+}
+
+func TestLibrary(t *testing.T) {
+	descriptorSet := filepath.Join(t.TempDir(), "method_test.binpb")
+
+	out, err := exec.Command("protoc", "-I=api", "-I=test", "--include_imports", "--include_source_info", "--descriptor_set_out="+descriptorSet, "test/method_test.proto").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	data, err := os.ReadFile(descriptorSet)
+	require.NoError(t, err)
+
+	set := &descriptorpb.FileDescriptorSet{}
+	require.NoError(t, proto.Unmarshal(data, set))
+
+	registry, err := protodesc.NewFiles(set)
+	require.NoError(t, err)
+
+	file, err := registry.FindFileByPath("method_test.proto")
+	require.NoError(t, err)
+
+	opts := openapi.Options{
+		Title:           "test title",
+		Description:     "test description",
+		Version:         "1.1.0",
+		DefaultResponse: "test.api.Error",
+	}
+
+	doc, err := openapi.Generate(context.Background(), []protoreflect.FileDescriptor{file}, opts)
+	require.NoError(t, err)
+
+	// The typed document doesn't have the empty properties given to the generated files, so
+	// only what it holds is compared with the generated file.
+	var expected struct {
+		Paths      map[string]map[string]any `yaml:"paths"`
+		Components struct {
+			Schemas map[string]any `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(readFile("method_test_openapi.yaml")), &expected))
+
+	assert.Equal(t, "test title", doc.Info.Title)
+	assert.Len(t, doc.Paths, len(expected.Paths))
+	for path, methods := range expected.Paths {
+		require.Contains(t, doc.Paths, path)
+		assert.Len(t, doc.Paths[path].Operations(), len(methods))
+	}
+	assert.Len(t, doc.Components.Schemas, len(expected.Components.Schemas))
+	for name := range expected.Components.Schemas {
+		assert.Contains(t, doc.Components.Schemas, name)
+	}
+
+	opts.OperationHooks = []openapi.OperationHook{
+		openapi.OperationHookFunc(func(_ context.Context, method protoreflect.MethodDescriptor, op *openapi3.Operation) error {
+			op.Extensions = map[string]any{"x-method": string(method.FullName())}
+			return nil
+		}),
+	}
+	opts.SchemaHooks = []openapi.SchemaHook{
+		openapi.SchemaHookFunc(func(_ context.Context, message protoreflect.MessageDescriptor, schema *openapi3.Schema) error {
+			schema.Extensions = map[string]any{"x-message": string(message.FullName())}
+			return nil
+		}),
+	}
+
+	doc, err = openapi.Generate(context.Background(), []protoreflect.FileDescriptor{file}, opts)
+	require.NoError(t, err)
+
+	for _, pathItem := range doc.Paths {
+		for _, op := range pathItem.Operations() {
+			assert.Contains(t, op.Extensions, "x-method")
+		}
+	}
+
+	for name, schemaRef := range doc.Components.Schemas {
+		assert.Equal(t, name, schemaRef.Value.Extensions["x-message"])
+	}
+
+	opts.SchemaHooks = []openapi.SchemaHook{
+		openapi.SchemaHookFunc(func(context.Context, protoreflect.MessageDescriptor, *openapi3.Schema) error {
+			return errors.New("hook failed")
+		}),
+	}
+	var diagnostics strings.Builder
+	opts.Diagnostics = &diagnostics
+
+	_, err = openapi.Generate(context.Background(), []protoreflect.FileDescriptor{file}, opts)
+	require.Error(t, err)
+	assert.Contains(t, diagnostics.String(), "method_test.proto:")
+	assert.Contains(t, diagnostics.String(), ": hook failed")
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	req := newBenchmarkRequest(20, 100)
+
+	for i := 0; i < b.N; i++ {
+		plugin, err := protogen.Options{}.New(req)
+		require.NoError(b, err)
+
+		conf := generator.Config{
+			BaseFile:          proto.String(""),
+			ComponentStrategy: proto.String("suffix"),
+			ContentType:       proto.String("application/json"),
+			DefaultResponse:   proto.String(""),
+			Description:       proto.String(""),
+			Filename:          proto.String("openapi"),
+			Host:              proto.String(""),
+			Ignore:            proto.String(""),
+			Include:           proto.String(""),
+			InputQuery:        proto.Bool(false),
+			JSONOutput:        proto.Bool(false),
+			OpenAPIVersion:    proto.String("3.0"),
+			OutputFormat:      proto.String("openapi"),
+			OutputMode:        proto.String("single"),
+			Overlays:          new(generator.StringList),
+			PathOrder:         proto.String("declaration"),
+			Routing:           proto.String(""),
+			SharedComponents:  proto.Bool(false),
+			Strict:            proto.Bool(false),
+			Title:             proto.String("benchmark"),
+			UseEnumNumbers:    proto.Bool(false),
+			UseJSONNames:      proto.Bool(false),
+			Version:           proto.String("0.0.1"),
+		}
+
+		require.NoError(b, generator.New(plugin, conf).Run())
+		require.Empty(b, plugin.Response().GetError())
+	}
+}
+
+// newBenchmarkRequest returns a request for the files with the number of messages each. Half of the
+// messages are the requests and responses of the methods of a service of the file and each message
+// references the previous one.
+func newBenchmarkRequest(files, messages int) *pluginpb.CodeGeneratorRequest {
+	req := &pluginpb.CodeGeneratorRequest{}
+
+	added := make(map[string]bool)
+
+	var addDependency func(file protoreflect.FileDescriptor)
+	addDependency = func(file protoreflect.FileDescriptor) {
+		if added[file.Path()] {
+			return
+		}
+		added[file.Path()] = true
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			addDependency(imports.Get(i).FileDescriptor)
+		}
+
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(file))
+	}
+	addDependency(oapiv1.File_oapi_v1_method_proto)
+
+	for i := 0; i < files; i++ {
+		name := fmt.Sprintf("bench/v1/file%d.proto", i)
+		file := &descriptorpb.FileDescriptorProto{
+			Name:       proto.String(name),
+			Package:    proto.String(fmt.Sprintf("bench.v1.file%d", i)),
+			Syntax:     proto.String("proto3"),
+			Dependency: []string{"oapi/v1/method.proto"},
+			Options: &descriptorpb.FileOptions{
+				GoPackage: proto.String(fmt.Sprintf("example.com/bench/v1/file%d", i)),
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{Name: proto.String(fmt.Sprintf("BenchService%d", i))}},
+		}
+
+		for j := 0; j < messages; j++ {
+			message := &descriptorpb.DescriptorProto{
+				Name: proto.String(fmt.Sprintf("Message%d", j)),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					newBenchmarkField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					newBenchmarkField("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+					newBenchmarkField("enabled", 3, descriptorpb.FieldDescriptorProto_TYPE_BOOL, ""),
+				},
+			}
+
+			if j > 0 {
+				message.Field = append(message.Field, newBenchmarkField("previous", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, fmt.Sprintf(".bench.v1.file%d.Message%d", i, j-1)))
+			}
+
+			file.MessageType = append(file.MessageType, message)
+
+			if j%2 == 0 {
+				continue
+			}
+
+			options := &descriptorpb.MethodOptions{}
+			proto.SetExtension(options, oapiv1.E_Method, &oapiv1.MethodOptions{
+				Method: &oapiv1.MethodOptions_Post{Post: fmt.Sprintf("/file%d/method%d", i, j)},
+			})
+
+			file.Service[0].Method = append(file.Service[0].Method, &descriptorpb.MethodDescriptorProto{
+				Name:       proto.String(fmt.Sprintf("Method%d", j)),
+				InputType:  proto.String(fmt.Sprintf(".bench.v1.file%d.Message%d", i, j-1)),
+				OutputType: proto.String(fmt.Sprintf(".bench.v1.file%d.Message%d", i, j)),
+				Options:    options,
+			})
+		}
+
+		req.ProtoFile = append(req.ProtoFile, file)
+		req.FileToGenerate = append(req.FileToGenerate, name)
+	}
+
+	return req
+}
+
+// newBenchmarkField returns a field of the benchmark messages.
+func newBenchmarkField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+		JsonName: proto.String(name),
+	}
+
+	if typeName != "" {
+		field.TypeName = proto.String(typeName)
+	}
+
+	return field
+}
+
 func readFile(name string) string {
 	data, _ := os.ReadFile("test/" + name)
 	return string(data)
@@ -0,0 +1,211 @@
+// Package openapi generates OpenAPI documents from Protobuf files for embedding the generator in
+// build tooling. It builds the same document as the protoc plugin's single output mode and hooks
+// can change operations and schemas as they're built, e.g. to add vendor extensions.
+package openapi
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/technicallyjosh/protoc-gen-openapi/internal/generator"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Options are the options of the generation. They're the same as the plugin options of the same
+// names and the zero value uses the same defaults.
+type Options struct {
+	// Title is the title of the API.
+	Title string
+	// Description is the description of the API.
+	Description string
+	// Version is the version of the API. Defaults to 0.0.1.
+	Version string
+	// ContentType is the content type of all operations. Defaults to application/json.
+	ContentType string
+	// DefaultResponse is the message used for responses that aren't defined.
+	DefaultResponse string
+	// Host is the host of all operations.
+	Host string
+	// Include holds the packages to include only. Ignore is applied after it.
+	Include []string
+	// Ignore holds the packages to ignore.
+	Ignore []string
+	// ComponentStrategy is which messages are component schemas: suffix, rpc or all. Defaults to
+	// suffix.
+	ComponentStrategy string
+	// InputQuery expands the input fields into query parameters for GET and DELETE methods.
+	InputQuery bool
+	// Routing routes methods without a path to their twirp or connect path.
+	Routing string
+	// UseEnumNumbers uses enum numbers instead of names for enum values.
+	UseEnumNumbers bool
+	// UseJSONNames uses the JSON names instead of the proto names of fields.
+	UseJSONNames bool
+	// Strict fails the generation on warnings.
+	Strict bool
+	// Diagnostics is where errors and warnings are written as "file.proto:line:column: message".
+	// Defaults to stderr.
+	Diagnostics io.Writer
+	// OperationHooks are called in order with each operation once it's built.
+	OperationHooks []OperationHook
+	// SchemaHooks are called in order with the schema of each message once it's built.
+	SchemaHooks []SchemaHook
+}
+
+// OperationHook is called with each operation once it's built, before it's added to the document.
+// An error fails the generation and is reported at the method.
+type OperationHook interface {
+	HookOperation(ctx context.Context, method protoreflect.MethodDescriptor, op *openapi3.Operation) error
+}
+
+// OperationHookFunc is a function used as an OperationHook.
+type OperationHookFunc func(ctx context.Context, method protoreflect.MethodDescriptor, op *openapi3.Operation) error
+
+// HookOperation calls f.
+func (f OperationHookFunc) HookOperation(ctx context.Context, method protoreflect.MethodDescriptor, op *openapi3.Operation) error {
+	return f(ctx, method, op)
+}
+
+// SchemaHook is called with the schema of each message once it's built. Component schemas are
+// passed once and messages built inline each time they're built. An error fails the generation and
+// is reported at the message.
+type SchemaHook interface {
+	HookSchema(ctx context.Context, message protoreflect.MessageDescriptor, schema *openapi3.Schema) error
+}
+
+// SchemaHookFunc is a function used as a SchemaHook.
+type SchemaHookFunc func(ctx context.Context, message protoreflect.MessageDescriptor, schema *openapi3.Schema) error
+
+// HookSchema calls f.
+func (f SchemaHookFunc) HookSchema(ctx context.Context, message protoreflect.MessageDescriptor, schema *openapi3.Schema) error {
+	return f(ctx, message, schema)
+}
+
+// Generate generates the OpenAPI 3.0 document of the files. Their imports are resolved through the
+// descriptors, so they don't need to be passed. Errors and warnings are written to the diagnostics
+// of the options as they're found and generation stops once all of them are.
+func Generate(ctx context.Context, files []protoreflect.FileDescriptor, opts Options) (*openapi3.T, error) {
+	err := ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	plugin, err := newPlugin(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return generator.New(plugin, newConfig(ctx, opts)).Build()
+}
+
+// newConfig returns the generator config of the options with the defaults of the plugin options.
+func newConfig(ctx context.Context, opts Options) generator.Config {
+	conf := generator.Config{
+		BaseFile:          proto.String(""),
+		ComponentStrategy: proto.String(withDefault(opts.ComponentStrategy, "suffix")),
+		ContentType:       proto.String(withDefault(opts.ContentType, "application/json")),
+		DefaultResponse:   proto.String(opts.DefaultResponse),
+		Description:       proto.String(opts.Description),
+		Filename:          proto.String("openapi"),
+		Host:              proto.String(opts.Host),
+		Ignore:            proto.String(strings.Join(opts.Ignore, "|")),
+		Include:           proto.String(strings.Join(opts.Include, "|")),
+		InputQuery:        proto.Bool(opts.InputQuery),
+		JSONOutput:        proto.Bool(false),
+		OpenAPIVersion:    proto.String("3.0"),
+		OutputFormat:      proto.String("openapi"),
+		OutputMode:        proto.String("single"),
+		Overlays:          new(generator.StringList),
+		PathOrder:         proto.String("declaration"),
+		Routing:           proto.String(opts.Routing),
+		SharedComponents:  proto.Bool(false),
+		Strict:            proto.Bool(opts.Strict),
+		Title:             proto.String(opts.Title),
+		UseEnumNumbers:    proto.Bool(opts.UseEnumNumbers),
+		UseJSONNames:      proto.Bool(opts.UseJSONNames),
+		Version:           proto.String(withDefault(opts.Version, "0.0.1")),
+		Diagnostics:       opts.Diagnostics,
+	}
+
+	if len(opts.OperationHooks) > 0 {
+		conf.OperationHook = func(method *protogen.Method, op *openapi3.Operation) error {
+			for _, hook := range opts.OperationHooks {
+				err := hook.HookOperation(ctx, method.Desc, op)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	if len(opts.SchemaHooks) > 0 {
+		conf.SchemaHook = func(message *protogen.Message, schema *openapi3.Schema) error {
+			for _, hook := range opts.SchemaHooks {
+				err := hook.HookSchema(ctx, message.Desc, schema)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return conf
+}
+
+// newPlugin returns the plugin protoc would run for the files.
+func newPlugin(files []protoreflect.FileDescriptor) (*protogen.Plugin, error) {
+	req := &pluginpb.CodeGeneratorRequest{}
+
+	// Dependencies come before the files that import them, like protoc sends them.
+	added := make(map[string]bool)
+
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if added[file.Path()] {
+			return
+		}
+		added[file.Path()] = true
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(file))
+	}
+
+	for _, file := range files {
+		add(file)
+		req.FileToGenerate = append(req.FileToGenerate, file.Path())
+	}
+
+	// protogen requires a Go import path for every file, which doesn't matter since no Go is
+	// generated.
+	params := make([]string, 0, len(req.ProtoFile))
+	for _, file := range req.ProtoFile {
+		params = append(params, "M"+file.GetName()+"=proto/"+strings.TrimSuffix(file.GetName(), ".proto"))
+	}
+
+	req.Parameter = proto.String(strings.Join(params, ","))
+
+	return protogen.Options{}.New(req)
+}
+
+// withDefault returns the value or the default if it's empty.
+func withDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}